@@ -44,15 +44,15 @@ func (c *Client) GetAdjFactor(params AdjFactorParams, fields []string) (*types.D
 	}
 
 	if params.TradeDate != "" {
-		reqParams["trade_date"] = params.TradeDate
+		reqParams["trade_date"] = types.Date(params.TradeDate)
 	}
 
 	if params.StartDate != "" {
-		reqParams["start_date"] = params.StartDate
+		reqParams["start_date"] = types.Date(params.StartDate)
 	}
 
 	if params.EndDate != "" {
-		reqParams["end_date"] = params.EndDate
+		reqParams["end_date"] = types.Date(params.EndDate)
 	}
 
 	// 调用通用查询接口
@@ -0,0 +1,210 @@
+package client
+
+import (
+	"math"
+	"sort"
+
+	"github.com/Premium-Platform/go-tushare/pkg/types"
+)
+
+const (
+	// AdjustNone 不复权
+	AdjustNone = "None"
+	// AdjustQFQ 前复权
+	AdjustQFQ = "qfq"
+	// AdjustHFQ 后复权
+	AdjustHFQ = "hfq"
+)
+
+// adjustPriceFields 需要复权的价格字段
+var adjustPriceFields = []string{"open", "high", "low", "close", "pre_close", "change", "up_limit", "down_limit"}
+
+// dateFactor 某个交易日的复权因子
+type dateFactor struct {
+	date   string
+	factor float64
+}
+
+// adjustBar 复权处理
+//
+// 与TuShare pro_bar一致：
+// - 后复权价格 = 价格 × 当日复权因子
+// - 前复权价格 = 价格 × 当日复权因子 / 锚定复权因子
+//
+// 前复权的锚定复权因子取全部历史中最新的复权因子（而非查询区间内的），因此结果不随StartDate变化；
+// 指定AdjustAnchor时锚定该日期（含）之前最近的复权因子。
+func (c *Client) adjustBar(df *types.DataFrame, params BarParams) (*types.DataFrame, error) {
+	if df == nil || len(df.Rows) == 0 {
+		return df, nil
+	}
+
+	// 前复权需要获取至最新的复权因子；指定锚定日期时获取覆盖查询区间和锚定日期的复权因子，
+	// 锚定日期之后的行情仍使用各自当日的复权因子
+	startDate, endDate := datePart(params.StartDate), datePart(params.EndDate)
+	if params.AdjustType == AdjustQFQ {
+		anchor := params.AdjustAnchor
+		switch {
+		case anchor == "":
+			endDate = ""
+		case endDate != "" && anchor > endDate:
+			endDate = anchor
+		}
+		if anchor != "" && (startDate == "" || anchor < startDate) {
+			startDate = anchor
+		}
+	}
+
+	// 获取复权因子（场内基金使用fund_adj）
+	factorAPI := "adj_factor"
+	if params.AssetType == "FD" {
+		factorAPI = "fund_adj"
+	}
+
+	c.logger.Debug("正在获取复权因子, api=%s, ts_code=%s, start_date=%s, end_date=%s",
+		factorAPI, params.TsCode, startDate, endDate)
+
+	reqParams := map[string]interface{}{
+		"ts_code": params.TsCode,
+	}
+	if startDate != "" {
+		reqParams["start_date"] = startDate
+	}
+	if endDate != "" {
+		reqParams["end_date"] = endDate
+	}
+
+	fcts, err := c.Query(factorAPI, reqParams, []string{"trade_date", "adj_factor"})
+	if err != nil {
+		c.logger.Error("获取复权因子失败: %v", err)
+		return nil, err
+	}
+
+	factors := parseFactors(fcts)
+	if len(factors) == 0 {
+		c.logger.Warn("未找到复权因子数据, 将使用未复权数据")
+		return df, nil
+	}
+
+	// 前复权锚定因子
+	anchor := factors[len(factors)-1].factor
+	if params.AdjustAnchor != "" {
+		anchor = factorAt(factors, params.AdjustAnchor)
+	}
+
+	df = adjustRows(df, factors, params.AdjustType, anchor, params.AdjustVolume, priceDecimals(params.AssetType))
+
+	c.logger.Debug("复权处理完成, 处理类型: %s", params.AdjustType)
+	return df, nil
+}
+
+// parseFactors 解析复权因子数据，按交易日期升序排列
+func parseFactors(fcts *types.DataFrame) []dateFactor {
+	if fcts == nil {
+		return nil
+	}
+
+	factors := make([]dateFactor, 0, len(fcts.Rows))
+	for _, row := range fcts.Rows {
+		date, ok := row["trade_date"].(string)
+		if !ok {
+			continue
+		}
+		factor, ok := types.ToFloat64(row["adj_factor"])
+		if !ok || factor <= 0 {
+			continue
+		}
+		factors = append(factors, dateFactor{date: date, factor: factor})
+	}
+
+	sort.Slice(factors, func(i, j int) bool {
+		return factors[i].date < factors[j].date
+	})
+	return factors
+}
+
+// factorAt 获取指定日期（含）之前最近的复权因子，早于全部因子时取最早的因子
+func factorAt(factors []dateFactor, date string) float64 {
+	idx := sort.Search(len(factors), func(i int) bool {
+		return factors[i].date > date
+	})
+	if idx == 0 {
+		return factors[0].factor
+	}
+	return factors[idx-1].factor
+}
+
+// adjustRows 对行情数据应用复权因子，factors需按日期升序排列，anchor为前复权锚定因子，decimals为价格保留的小数位数
+func adjustRows(df *types.DataFrame, factors []dateFactor, adjustType string, anchor float64, adjustVolume bool, decimals int) *types.DataFrame {
+	for i, row := range df.Rows {
+		date := rowTradeDate(row)
+		if date == "" {
+			continue
+		}
+
+		ratio := factorAt(factors, date)
+		switch adjustType {
+		case AdjustQFQ:
+			ratio = ratio / anchor
+		case AdjustHFQ:
+		default:
+			continue
+		}
+
+		df.Rows[i] = applyAdjustFactor(row, ratio, adjustVolume, decimals)
+	}
+
+	return df
+}
+
+// rowTradeDate 获取行的交易日期（YYYYMMDD），没有trade_date时取trade_time的日期部分
+func rowTradeDate(row map[string]interface{}) string {
+	if date, ok := row["trade_date"].(string); ok && date != "" {
+		return date
+	}
+	if t, ok := row["trade_time"].(string); ok && t != "" {
+		return datePart(t)
+	}
+	return ""
+}
+
+// applyAdjustFactor 应用复权因子到行情数据
+//
+// 价格保留decimals位小数（见priceDecimals）；成交量复权时按因子反向调整，使成交额保持不变
+func applyAdjustFactor(row map[string]interface{}, factor float64, adjustVolume bool, decimals int) map[string]interface{} {
+	// 复制原始数据
+	result := make(map[string]interface{})
+	for k, v := range row {
+		result[k] = v
+	}
+
+	// 对价格字段应用复权因子
+	for _, field := range adjustPriceFields {
+		if price, ok := types.ToFloat64(row[field]); ok {
+			result[field] = roundPrice(price*factor, decimals)
+		}
+	}
+
+	// 对成交量应用复权因子
+	if adjustVolume && factor != 0 {
+		if vol, ok := types.ToFloat64(row["vol"]); ok {
+			result["vol"] = vol / factor
+		}
+	}
+
+	return result
+}
+
+// priceDecimals 复权价格保留的小数位数，与各资产类别的最小价格变动单位一致：
+// 股票0.01元，与TuShare pro_bar相同保留两位；场内基金0.001元，保留三位
+func priceDecimals(assetType string) int {
+	if assetType == "FD" {
+		return 3
+	}
+	return 2
+}
+
+// roundPrice 价格保留decimals位小数
+func roundPrice(v float64, decimals int) float64 {
+	scale := math.Pow(10, float64(decimals))
+	return math.Round(v*scale) / scale
+}
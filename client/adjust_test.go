@@ -0,0 +1,207 @@
+package client
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Premium-Platform/go-tushare/pkg/types"
+)
+
+// adjFactorFixture 复权因子样例：20240105除息（1.0 -> 1.1），20240110每股拆为两股（1.1 -> 2.2）
+var adjFactorFixture = [][]interface{}{
+	{"20231229", 1.0},
+	{"20240102", 1.0},
+	{"20240104", 1.0},
+	{"20240105", 1.1},
+	{"20240109", 1.1},
+	{"20240110", 2.2},
+	{"20240111", 2.2},
+}
+
+// barFixture 未复权日线样例，与adjFactorFixture对应
+func barFixture() *types.DataFrame {
+	rows := []map[string]interface{}{
+		{"trade_date": "20240102", "close": 10.0, "vol": 100.0},
+		{"trade_date": "20240104", "close": 10.0, "vol": 100.0},
+		{"trade_date": "20240105", "close": 9.0, "vol": 100.0},
+		{"trade_date": "20240109", "close": 9.0, "vol": 100.0},
+		{"trade_date": "20240110", "close": 4.5, "vol": 200.0},
+		{"trade_date": "20240111", "close": 4.6, "vol": 200.0},
+	}
+	return types.NewDataFrame([]string{"trade_date", "close", "vol"}, rows)
+}
+
+// factorFixture 复权因子样例转换为dateFactor
+func factorFixture() []dateFactor {
+	columns := []string{"trade_date", "adj_factor"}
+	rows := make([]map[string]interface{}, 0, len(adjFactorFixture))
+	for _, item := range adjFactorFixture {
+		rows = append(rows, map[string]interface{}{"trade_date": item[0], "adj_factor": item[1]})
+	}
+	return parseFactors(types.NewDataFrame(columns, rows))
+}
+
+// closes 按交易日期获取收盘价
+func closes(df *types.DataFrame) map[string]float64 {
+	result := make(map[string]float64, len(df.Rows))
+	for _, row := range df.Rows {
+		result[row["trade_date"].(string)], _ = types.ToFloat64(row["close"])
+	}
+	return result
+}
+
+func assertCloses(t *testing.T, df *types.DataFrame, want map[string]float64) {
+	t.Helper()
+	got := closes(df)
+	for date, w := range want {
+		if g, ok := got[date]; !ok || math.Abs(g-w) > 1e-9 {
+			t.Errorf("close on %s = %v, want %v", date, g, w)
+		}
+	}
+}
+
+func TestAdjustRows(t *testing.T) {
+	factors := factorFixture()
+
+	tests := []struct {
+		name   string
+		anchor string
+		want   map[string]float64
+	}{
+		{
+			// 锚定最新的复权因子2.2
+			name: "latest anchor",
+			want: map[string]float64{
+				"20240102": 4.55, // 10 × 1.0 / 2.2
+				"20240105": 4.5,  // 9 × 1.1 / 2.2
+				"20240109": 4.5,
+				"20240110": 4.5,
+				"20240111": 4.6,
+			},
+		},
+		{
+			// 锚定除息日，之后的拆股仍按当日因子调整
+			name:   "explicit anchor",
+			anchor: "20240105",
+			want: map[string]float64{
+				"20240102": 9.09, // 10 × 1.0 / 1.1
+				"20240105": 9.0,
+				"20240110": 9.0, // 4.5 × 2.2 / 1.1
+				"20240111": 9.2, // 4.6 × 2.2 / 1.1
+			},
+		},
+		{
+			// 锚定日期早于查询区间，结果等价于以锚定日为基准的后复权
+			name:   "anchor before start date",
+			anchor: "20231229",
+			want: map[string]float64{
+				"20240104": 10.0,
+				"20240105": 9.9,   // 9 × 1.1
+				"20240110": 9.9,   // 4.5 × 2.2
+				"20240111": 10.12, // 4.6 × 2.2
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			anchor := factors[len(factors)-1].factor
+			if tt.anchor != "" {
+				anchor = factorAt(factors, tt.anchor)
+			}
+			df := adjustRows(barFixture(), factors, AdjustQFQ, anchor, false, 2)
+			assertCloses(t, df, tt.want)
+		})
+	}
+}
+
+func TestAdjustRowsVolume(t *testing.T) {
+	factors := factorFixture()
+	df := adjustRows(barFixture(), factors, AdjustQFQ, 2.2, true, 2)
+
+	// 成交量按因子反向调整：拆股前100股相当于拆股后220股
+	for _, row := range df.Rows {
+		if row["trade_date"] == "20240102" {
+			if vol, _ := types.ToFloat64(row["vol"]); math.Abs(vol-220) > 1e-9 {
+				t.Errorf("vol on 20240102 = %v, want 220", vol)
+			}
+		}
+	}
+}
+
+func TestAdjustRowsHFQ(t *testing.T) {
+	df := adjustRows(barFixture(), factorFixture(), AdjustHFQ, 0, false, 2)
+	assertCloses(t, df, map[string]float64{
+		"20240102": 10.0,
+		"20240105": 9.9,
+		"20240111": 10.12,
+	})
+}
+
+// TestAdjustBarAnchorFetchesLaterFactors 指定锚定日期时，锚定日期之后的行情需要各自的复权因子
+func TestAdjustBarAnchorFetchesLaterFactors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Params map[string]string `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatal(err)
+		}
+		items := make([][]interface{}, 0)
+		for _, item := range adjFactorFixture {
+			date := item[0].(string)
+			if start := req.Params["start_date"]; start != "" && date < start {
+				continue
+			}
+			if end := req.Params["end_date"]; end != "" && date > end {
+				continue
+			}
+			items = append(items, item)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"code": 0,
+			"data": map[string]interface{}{"fields": []string{"trade_date", "adj_factor"}, "items": items},
+		})
+	}))
+	defer srv.Close()
+
+	c := New("token")
+	c.SetAPIURL(srv.URL)
+
+	df, err := c.adjustBar(barFixture(), BarParams{
+		TsCode:       "000001.SZ",
+		StartDate:    "20240102",
+		EndDate:      "20240111",
+		AdjustType:   AdjustQFQ,
+		AdjustAnchor: "20240105",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertCloses(t, df, map[string]float64{
+		"20240102": 9.09,
+		"20240110": 9.0,
+		"20240111": 9.2,
+	})
+}
+
+// TestAdjustRowsFundPrecision 场内基金复权价格保留三位小数
+func TestAdjustRowsFundPrecision(t *testing.T) {
+	rows := []map[string]interface{}{
+		{"trade_date": "20240102", "close": 1.234},
+		{"trade_date": "20240110", "close": 1.5},
+	}
+	df := types.NewDataFrame([]string{"trade_date", "close"}, rows)
+	df = adjustRows(df, factorFixture(), AdjustQFQ, 2.2, false, priceDecimals("FD"))
+	assertCloses(t, df, map[string]float64{
+		"20240102": 0.561, // 1.234 × 1.0 / 2.2 = 0.56090...
+		"20240110": 1.5,
+	})
+
+	if got := priceDecimals("E"); got != 2 {
+		t.Errorf("priceDecimals(E) = %d, want 2", got)
+	}
+}
@@ -154,19 +154,19 @@ func (c *Client) GetBalanceSheet(params BalanceSheetParams, fields []string) (*t
 	}
 
 	if params.AnnDate != "" {
-		reqParams["ann_date"] = params.AnnDate
+		reqParams["ann_date"] = types.Date(params.AnnDate)
 	}
 
 	if params.StartDate != "" {
-		reqParams["start_date"] = params.StartDate
+		reqParams["start_date"] = types.Date(params.StartDate)
 	}
 
 	if params.EndDate != "" {
-		reqParams["end_date"] = params.EndDate
+		reqParams["end_date"] = types.Date(params.EndDate)
 	}
 
 	if params.Period != "" {
-		reqParams["period"] = params.Period
+		reqParams["period"] = types.Period(params.Period)
 	}
 
 	if params.ReportType != "" {
@@ -191,10 +191,14 @@ func (c *Client) GetLatestBalanceSheet(tsCode string) (*types.DataFrame, error)
 
 // GetYearBalanceSheet 获取年度资产负债表数据（简化接口）
 func (c *Client) GetYearBalanceSheet(tsCode string, year string) (*types.DataFrame, error) {
+	period, err := annualPeriod(year)
+	if err != nil {
+		return nil, err
+	}
 	return c.GetBalanceSheet(BalanceSheetParams{
 		TSCode:     tsCode,
-		Period:     year + "1231", // 年度报表日期
-		ReportType: "1",           // 合并报表
+		Period:     period, // 年度报表日期
+		ReportType: "1",    // 合并报表
 	}, nil)
 }
 
@@ -2,22 +2,50 @@ package client
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
+	tsError "github.com/Premium-Platform/go-tushare/pkg/errors"
+	"github.com/Premium-Platform/go-tushare/pkg/indicators"
+	"github.com/Premium-Platform/go-tushare/pkg/symbol"
 	"github.com/Premium-Platform/go-tushare/pkg/types"
 )
 
 // BarParams Bar接口参数
 type BarParams struct {
 	TsCode       string   // 证券代码
-	StartDate    string   // 开始日期 YYYYMMDD
-	EndDate      string   // 结束日期 YYYYMMDD
-	Freq         string   // 周期：D=日线 W=周线 M=月线
-	AssetType    string   // 资产类别：E=股票 I=指数 C=数字货币 FT=期货
+	StartDate    string   // 开始日期 YYYYMMDD，分钟线可使用 YYYY-MM-DD HH:MM:SS
+	EndDate      string   // 结束日期 YYYYMMDD，分钟线可使用 YYYY-MM-DD HH:MM:SS
+	Freq         string   // 周期：D=日线 W=周线 M=月线 1min/5min/15min/30min/60min=分钟线，其他周期如120min/3D/2W/Q在本地重采样
+	AssetType    string   // 资产类别：E=股票 I=指数 C=数字货币 FT=期货 FD=场内基金 O=期权 CB=可转债
 	Exchange     string   // 交易所
 	AdjustType   string   // 复权类型：None=不复权 qfq=前复权 hfq=后复权
+	AdjustAnchor string   // 前复权锚定日期 YYYYMMDD，为空时锚定最新的复权因子
+	AdjustVolume bool     // 是否同时对成交量复权
 	MA           []int    // 均线
-	Factors      []string // 因子数据
+	Factors      []string // 因子数据：vr=量比 tor=换手率 pe=市盈率 pb=市净率 mv=市值，以及技术指标如 macd(12,26,9)、kdj、rsi(14)
 	ContractType string   // 合约类型
+	Descending   bool     // 是否按时间降序返回，默认升序
+	Partial      string   // 本地重采样时末尾不完整周期的处理方式：keep=保留并标记is_partial（默认） drop=丢弃
+	Limit        string   // 涨跌停价格（仅股票日线）：空=不添加 api=取自stk_limit rule=按板块规则计算
+	Suspend      bool     // 是否标记停牌（仅股票日线），全天停牌的交易日补充空行
+	MultiMode    string   // BarMulti的获取方式：auto=自动选择（默认） by_code=按证券获取 by_date=按交易日获取（仅股票日线）
+}
+
+// AssetTypes Bar接口支持的资产类别
+var AssetTypes = []string{"E", "I", "C", "FT", "FD", "O", "CB"}
+
+// isSupportedAssetType 判断是否为支持的资产类别，空值视为股票
+func isSupportedAssetType(assetType string) bool {
+	if assetType == "" {
+		return true
+	}
+	for _, t := range AssetTypes {
+		if t == assetType {
+			return true
+		}
+	}
+	return false
 }
 
 // FreqMap 频率映射
@@ -29,29 +57,185 @@ var FreqMap = map[string]string{
 }
 
 // Bar 行情数据通用接口
+//
+// TuShare的日线、周线等接口按交易日期降序返回，Bar统一将结果整理为按时间升序，
+// 均线、量比等衍生列均在时间升序上计算；Descending为true时在计算完成后再转为降序返回。
 func (c *Client) Bar(params BarParams) (*types.DataFrame, error) {
-	// 股票
-	if params.AssetType == "E" || params.AssetType == "" {
-		return c.stockBar(params)
+	var df *types.DataFrame
+	var err error
+
+	if !isSupportedAssetType(params.AssetType) {
+		return nil, tsError.Wrapf(tsError.ErrInvalidParameter, "unsupported asset type %s", params.AssetType)
+	}
+	if err = validateLimitParams(params); err != nil {
+		return nil, err
+	}
+	if err = normalizeBarDates(&params); err != nil {
+		return nil, err
+	}
+	if params.TsCode, err = normalizeBarCode(params.AssetType, params.TsCode); err != nil {
+		return nil, err
+	}
+
+	// TuShare不直接提供的周期，在本地重采样
+	if !isNativeFreq(params.AssetType, params.Freq) {
+		df, err = c.resampleBar(params)
+		if err != nil {
+			return nil, err
+		}
+		return c.finishBar(df, params)
+	}
+
+	// 分钟行情
+	if isMinuteFreq(params.Freq) {
+		df, err = c.minuteBar(params)
+		if err != nil {
+			return nil, err
+		}
+		return c.finishBar(df, params)
+	}
+
+	switch params.AssetType {
+	case "E", "":
+		// 股票
+		df, err = c.stockBar(params)
+	case "I":
+		// 指数
+		df, err = c.indexBar(params)
+	case "FT":
+		// 期货
+		df, err = c.futureBar(params)
+	case "C":
+		// 数字货币
+		df, err = c.coinBar(params)
+	case "FD":
+		// 场内基金
+		df, err = c.fundBar(params)
+	case "O":
+		// 期权
+		df, err = c.optionBar(params)
+	case "CB":
+		// 可转债
+		df, err = c.cbBar(params)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return c.finishBar(df, params)
+}
+
+// finishBar 统一整理行情数据的顺序，并计算衍生列
+func (c *Client) finishBar(df *types.DataFrame, params BarParams) (*types.DataFrame, error) {
+	if df == nil || len(df.Rows) == 0 {
+		return df, nil
 	}
 
-	// 指数
-	if params.AssetType == "I" {
-		return c.indexBar(params)
+	var err error
+
+	// 按时间升序排列
+	sortBarAscending(df)
+
+	// 如果需要计算因子
+	if len(params.Factors) > 0 {
+		df, err = c.addFactors(df, params)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	// 期货
-	if params.AssetType == "FT" {
-		return c.futureBar(params)
+	// 如果需要计算均线
+	if len(params.MA) > 0 {
+		df, err = c.calculateMA(df, params.MA)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	// 数字货币
-	if params.AssetType == "C" {
-		return c.coinBar(params)
+	// 按需转为降序
+	if params.Descending {
+		df.Reverse()
 	}
 
-	// 默认使用股票接口
-	return c.stockBar(params)
+	return df, nil
+}
+
+// normalizeBarDates 检查行情查询的日期参数格式并统一格式：
+// 日期为YYYYMMDD，带时间的分钟线日期为YYYY-MM-DD HH:MM:SS
+func normalizeBarDates(params *BarParams) error {
+	for _, s := range []*string{&params.StartDate, &params.EndDate} {
+		dt := types.DateTime(*s)
+		if dt.IsZero() {
+			continue
+		}
+		if err := dt.Validate(); err != nil {
+			return err
+		}
+		if dt.HasTime() {
+			*s = dt.String()
+		} else {
+			*s = dt.Date().String()
+		}
+	}
+	return normalizeDates(&params.AdjustAnchor)
+}
+
+// normalizeDates 检查日期参数格式并统一为YYYYMMDD，空值不变
+func normalizeDates(dates ...*string) error {
+	for _, s := range dates {
+		if types.Date(*s).IsZero() {
+			continue
+		}
+		d, err := types.ParseDate(*s)
+		if err != nil {
+			return err
+		}
+		*s = string(d)
+	}
+	return nil
+}
+
+// annualPeriod 将年份（YYYY）转换为年报报告期YYYY1231
+func annualPeriod(year string) (string, error) {
+	y, err := strconv.Atoi(strings.TrimSpace(year))
+	if err != nil || y < 1000 || y > 9999 {
+		return "", tsError.Wrapf(tsError.ErrInvalidParameter, "invalid year %q", year)
+	}
+	p := types.AnnualPeriod(y)
+	if err := p.Validate(); err != nil {
+		return "", err
+	}
+	return string(p), nil
+}
+
+// barDate 获取行情查询日期参数的日期部分（YYYYMMDD），空值或无法解析时返回空字符串
+func barDate(s string) string {
+	return string(types.DateTime(s).Date())
+}
+
+// normalizeBarCode 将证券代码规范化为TuShare格式，数字货币代码不做处理
+func normalizeBarCode(assetType, code string) (string, error) {
+	if assetType == "C" {
+		return code, nil
+	}
+	return symbol.Normalize(code)
+}
+
+// barTimeColumn 返回行情数据的时间列名
+func barTimeColumn(df *types.DataFrame) string {
+	for _, col := range []string{"trade_time", "trade_date", "date"} {
+		if df.HasColumn(col) {
+			return col
+		}
+	}
+	return ""
+}
+
+// sortBarAscending 将行情数据按时间升序排列
+func sortBarAscending(df *types.DataFrame) {
+	if col := barTimeColumn(df); col != "" {
+		df.SortBy(col, true)
+	}
 }
 
 // stockBar 股票行情数据
@@ -73,8 +257,8 @@ func (c *Client) stockBar(params BarParams) (*types.DataFrame, error) {
 	// 构建请求参数
 	queryParams := map[string]interface{}{
 		"ts_code":    params.TsCode,
-		"start_date": params.StartDate,
-		"end_date":   params.EndDate,
+		"start_date": barDate(params.StartDate),
+		"end_date":   barDate(params.EndDate),
 	}
 
 	// 获取行情数据
@@ -83,25 +267,17 @@ func (c *Client) stockBar(params BarParams) (*types.DataFrame, error) {
 		return nil, err
 	}
 
-	// 如果需要复权处理
-	if params.AdjustType != "" && params.AdjustType != "None" {
-		df, err = c.adjustBar(df, params)
+	// 涨跌停、停牌标注基于未复权价格
+	if params.Limit != "" || params.Suspend {
+		df, err = c.annotateLimits(df, params)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	// 如果需要计算因子
-	if len(params.Factors) > 0 {
-		df, err = c.addFactors(df, params)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	// 如果需要计算均线
-	if len(params.MA) > 0 {
-		df, err = c.calculateMA(df, params.MA)
+	// 如果需要复权处理
+	if params.AdjustType != "" && params.AdjustType != AdjustNone {
+		df, err = c.adjustBar(df, params)
 		if err != nil {
 			return nil, err
 		}
@@ -129,25 +305,12 @@ func (c *Client) indexBar(params BarParams) (*types.DataFrame, error) {
 	// 构建请求参数
 	queryParams := map[string]interface{}{
 		"ts_code":    params.TsCode,
-		"start_date": params.StartDate,
-		"end_date":   params.EndDate,
+		"start_date": barDate(params.StartDate),
+		"end_date":   barDate(params.EndDate),
 	}
 
 	// 获取行情数据
-	df, err := c.Query(apiName, queryParams, []string{})
-	if err != nil {
-		return nil, err
-	}
-
-	// 如果需要计算均线
-	if len(params.MA) > 0 {
-		df, err = c.calculateMA(df, params.MA)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	return df, nil
+	return c.Query(apiName, queryParams, []string{})
 }
 
 // futureBar 期货行情数据
@@ -155,26 +318,13 @@ func (c *Client) futureBar(params BarParams) (*types.DataFrame, error) {
 	// 构建请求参数
 	queryParams := map[string]interface{}{
 		"ts_code":    params.TsCode,
-		"start_date": params.StartDate,
-		"end_date":   params.EndDate,
+		"start_date": barDate(params.StartDate),
+		"end_date":   barDate(params.EndDate),
 		"exchange":   params.Exchange,
 	}
 
 	// 获取行情数据
-	df, err := c.Query("fut_daily", queryParams, []string{})
-	if err != nil {
-		return nil, err
-	}
-
-	// 如果需要计算均线
-	if len(params.MA) > 0 {
-		df, err = c.calculateMA(df, params.MA)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	return df, nil
+	return c.Query("fut_daily", queryParams, []string{})
 }
 
 // coinBar 数字货币行情数据
@@ -188,170 +338,87 @@ func (c *Client) coinBar(params BarParams) (*types.DataFrame, error) {
 	// 构建请求参数
 	queryParams := map[string]interface{}{
 		"symbol":        params.TsCode,
-		"start_date":    params.StartDate,
-		"end_date":      params.EndDate,
+		"start_date":    barDate(params.StartDate),
+		"end_date":      barDate(params.EndDate),
 		"exchange":      params.Exchange,
 		"freq":          freq,
 		"contract_type": params.ContractType,
 	}
 
 	// 获取行情数据
-	df, err := c.Query("coinbar", queryParams, []string{})
-	if err != nil {
-		return nil, err
-	}
-
-	// 如果需要计算均线
-	if len(params.MA) > 0 {
-		df, err = c.calculateMA(df, params.MA)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	return df, nil
+	return c.Query("coinbar", queryParams, []string{})
 }
 
-// adjustBar 复权处理
-func (c *Client) adjustBar(df *types.DataFrame, params BarParams) (*types.DataFrame, error) {
-	if df == nil || len(df.Rows) == 0 {
-		return df, nil
+// fundBar 场内基金行情数据
+//
+// 使用fund_daily接口，复权因子来自fund_adj；场内基金的最小价格变动单位为0.001元，复权价格保留三位小数
+func (c *Client) fundBar(params BarParams) (*types.DataFrame, error) {
+	// 构建请求参数
+	queryParams := map[string]interface{}{
+		"ts_code":    params.TsCode,
+		"start_date": barDate(params.StartDate),
+		"end_date":   barDate(params.EndDate),
 	}
 
-	// 获取复权因子
-	c.logger.Debug("正在获取复权因子, ts_code=%s, start_date=%s, end_date=%s",
-		params.TsCode, params.StartDate, params.EndDate)
-
-	fcts, err := c.GetAdjFactor(AdjFactorParams{
-		TSCode:    params.TsCode,
-		StartDate: params.StartDate,
-		EndDate:   params.EndDate,
-	}, nil)
-
+	// 获取行情数据
+	df, err := c.Query("fund_daily", queryParams, []string{})
 	if err != nil {
-		c.logger.Error("获取复权因子失败: %v", err)
 		return nil, err
 	}
 
-	if fcts == nil || len(fcts.Rows) == 0 {
-		c.logger.Warn("未找到复权因子数据, 将使用未复权数据")
-		return df, nil
-	}
-
-	// 创建日期到复权因子的映射
-	adjFactorMap := make(map[string]float64)
-	var lastFactor float64 = 1.0
-
-	for _, row := range fcts.Rows {
-		date, ok := row["trade_date"].(string)
-		if !ok {
-			continue
-		}
-
-		factor, ok := row["adj_factor"].(float64)
-		if !ok {
-			// 尝试从字符串转换
-			if factorStr, ok := row["adj_factor"].(string); ok {
-				if f, err := stringToFloat(factorStr); err == nil {
-					factor = f
-				} else {
-					continue
-				}
-			} else {
-				continue
-			}
+	// 如果需要复权处理
+	if params.AdjustType != "" && params.AdjustType != AdjustNone {
+		df, err = c.adjustBar(df, params)
+		if err != nil {
+			return nil, err
 		}
-
-		adjFactorMap[date] = factor
-		lastFactor = factor
 	}
 
-	// 如果没有复权因子数据，则返回原始数据
-	if len(adjFactorMap) == 0 {
-		c.logger.Warn("复权因子数据为空, 将使用未复权数据")
-		return df, nil
-	}
-
-	// 获取第一个交易日的复权因子（用于前复权）
-	var firstFactor float64 = 0
-	for _, row := range fcts.Rows {
-		if factor, ok := row["adj_factor"].(float64); ok {
-			firstFactor = factor
-			break
-		} else if factorStr, ok := row["adj_factor"].(string); ok {
-			if f, err := stringToFloat(factorStr); err == nil {
-				firstFactor = f
-				break
-			}
-		}
-	}
+	return df, nil
+}
 
-	if firstFactor == 0 {
-		firstFactor = 1.0
+// optionBar 期权行情数据
+//
+// 使用opt_daily接口，期权合约不做复权处理
+func (c *Client) optionBar(params BarParams) (*types.DataFrame, error) {
+	if params.AdjustType != "" && params.AdjustType != AdjustNone {
+		c.logger.Warn("期权行情不支持复权, 将使用未复权数据")
 	}
 
-	// 对每一行数据进行复权处理
-	for i, row := range df.Rows {
-		date, ok := row["trade_date"].(string)
-		if !ok {
-			continue
-		}
-
-		factor, ok := adjFactorMap[date]
-		if !ok {
-			// 如果当前日期没有复权因子，使用最近的复权因子
-			factor = lastFactor
-		}
-
-		// 复权处理
-		if params.AdjustType == "qfq" { // 前复权
-			adjFactor := factor / firstFactor
-			df.Rows[i] = applyAdjustFactor(row, adjFactor)
-		} else if params.AdjustType == "hfq" { // 后复权
-			df.Rows[i] = applyAdjustFactor(row, factor)
-		}
+	// 构建请求参数
+	queryParams := map[string]interface{}{
+		"ts_code":    params.TsCode,
+		"start_date": barDate(params.StartDate),
+		"end_date":   barDate(params.EndDate),
+		"exchange":   params.Exchange,
 	}
 
-	c.logger.Debug("复权处理完成, 处理类型: %s", params.AdjustType)
-	return df, nil
+	// 获取行情数据
+	return c.Query("opt_daily", queryParams, []string{})
 }
 
-// applyAdjustFactor 应用复权因子到行情数据
-func applyAdjustFactor(row map[string]interface{}, factor float64) map[string]interface{} {
-	// 复制原始数据
-	result := make(map[string]interface{})
-	for k, v := range row {
-		result[k] = v
-	}
-
-	// 价格字段列表
-	priceFields := []string{"open", "high", "low", "close", "pre_close"}
-
-	// 对价格字段应用复权因子
-	for _, field := range priceFields {
-		if value, ok := row[field]; ok {
-			switch v := value.(type) {
-			case float64:
-				result[field] = v * factor
-			case string:
-				if price, err := stringToFloat(v); err == nil {
-					result[field] = price * factor
-				}
-			}
-		}
+// cbBar 可转债行情数据
+//
+// 使用cb_daily接口，可转债价格不做复权处理（转股价调整不影响债券价格）
+func (c *Client) cbBar(params BarParams) (*types.DataFrame, error) {
+	if params.AdjustType != "" && params.AdjustType != AdjustNone {
+		c.logger.Warn("可转债行情不支持复权, 将使用未复权数据")
 	}
 
-	return result
-}
+	// 构建请求参数
+	queryParams := map[string]interface{}{
+		"ts_code":    params.TsCode,
+		"start_date": barDate(params.StartDate),
+		"end_date":   barDate(params.EndDate),
+	}
 
-// stringToFloat 将字符串转换为浮点数
-func stringToFloat(s string) (float64, error) {
-	var f float64
-	_, err := fmt.Sscanf(s, "%f", &f)
-	return f, err
+	// 获取行情数据
+	return c.Query("cb_daily", queryParams, []string{})
 }
 
 // calculateMA 计算均线
+//
+// 缺失值（停牌、接口返回null）不按0参与计算：窗口内存在缺失值或数据不足一个周期时，均线结果为nil
 func (c *Client) calculateMA(df *types.DataFrame, ma []int) (*types.DataFrame, error) {
 	if df == nil || len(df.Rows) == 0 || len(ma) == 0 {
 		return df, nil
@@ -359,30 +426,6 @@ func (c *Client) calculateMA(df *types.DataFrame, ma []int) (*types.DataFrame, e
 
 	c.logger.Debug("开始计算均线, 周期: %v", ma)
 
-	// 提取收盘价数据
-	closes := make([]float64, len(df.Rows))
-	volumes := make([]float64, len(df.Rows))
-
-	for i, row := range df.Rows {
-		// 获取收盘价
-		if close, ok := row["close"].(float64); ok {
-			closes[i] = close
-		} else if closeStr, ok := row["close"].(string); ok {
-			if c, err := stringToFloat(closeStr); err == nil {
-				closes[i] = c
-			}
-		}
-
-		// 获取成交量
-		if vol, ok := row["vol"].(float64); ok {
-			volumes[i] = vol
-		} else if volStr, ok := row["vol"].(string); ok {
-			if v, err := stringToFloat(volStr); err == nil {
-				volumes[i] = v
-			}
-		}
-	}
-
 	// 计算各周期均线
 	for _, period := range ma {
 		if period <= 0 {
@@ -390,40 +433,25 @@ func (c *Client) calculateMA(df *types.DataFrame, ma []int) (*types.DataFrame, e
 		}
 
 		// 计算价格均线
-		maField := fmt.Sprintf("ma%d", period)
-		for i := range df.Rows {
-			if i >= period-1 {
-				sum := 0.0
-				for j := 0; j < period; j++ {
-					sum += closes[i-j]
-				}
-				df.Rows[i][maField] = sum / float64(period)
-			} else {
-				// 数据不足一个周期时，设为当前收盘价
-				df.Rows[i][maField] = closes[i]
-			}
-		}
+		df.SetColumn(fmt.Sprintf("ma%d", period), df.Rolling("close", period).Mean())
 
 		// 计算成交量均线
-		volMaField := fmt.Sprintf("vol_ma%d", period)
-		for i := range df.Rows {
-			if i >= period-1 {
-				sum := 0.0
-				for j := 0; j < period; j++ {
-					sum += volumes[i-j]
-				}
-				df.Rows[i][volMaField] = sum / float64(period)
-			} else {
-				// 数据不足一个周期时，设为当前成交量
-				df.Rows[i][volMaField] = volumes[i]
-			}
-		}
+		df.SetColumn(fmt.Sprintf("vol_ma%d", period), df.Rolling("vol", period).Mean())
 	}
 
 	c.logger.Debug("均线计算完成")
 	return df, nil
 }
 
+// dailyBasicFactors 由每日指标(daily_basic)提供的因子及对应字段
+var dailyBasicFactors = map[string][]string{
+	"tor":           {DailyBasicField.TurnoverRate, DailyBasicField.TurnoverRateF},
+	"turnover_rate": {DailyBasicField.TurnoverRate, DailyBasicField.TurnoverRateF},
+	"pe":            {DailyBasicField.PE, DailyBasicField.PETTM},
+	"pb":            {DailyBasicField.PB},
+	"mv":            {DailyBasicField.TotalMV, DailyBasicField.CircMV},
+}
+
 // addFactors 添加因子数据
 func (c *Client) addFactors(df *types.DataFrame, params BarParams) (*types.DataFrame, error) {
 	if df == nil || len(df.Rows) == 0 || len(params.Factors) == 0 {
@@ -432,13 +460,35 @@ func (c *Client) addFactors(df *types.DataFrame, params BarParams) (*types.DataF
 
 	c.logger.Debug("开始计算因子数据, 因子: %v", params.Factors)
 
+	// 需要从每日指标中获取的字段
+	var basicFields []string
+
 	// 处理不同的因子
 	for _, factor := range params.Factors {
+		if fields, ok := dailyBasicFactors[factor]; ok {
+			for _, field := range fields {
+				if !containsField(basicFields, field) {
+					basicFields = append(basicFields, field)
+				}
+			}
+			continue
+		}
+
 		switch factor {
 		case "vr", "volume_ratio":
 			df = c.calculateVolumeRatio(df)
-		case "tor", "turnover_rate":
-			df = c.calculateTurnoverRate(df, params.TsCode)
+		default:
+			// 技术指标，如 macd(12,26,9)
+			if err := indicators.Apply(df, factor); err != nil {
+				c.logger.Error("计算技术指标失败: %v", err)
+				return nil, err
+			}
+		}
+	}
+
+	if len(basicFields) > 0 {
+		if err := c.joinDailyBasic(df, params, basicFields); err != nil {
+			return nil, err
 		}
 	}
 
@@ -446,56 +496,69 @@ func (c *Client) addFactors(df *types.DataFrame, params BarParams) (*types.DataF
 	return df, nil
 }
 
+// joinDailyBasic 获取每日指标并按交易日期合并到行情数据
+func (c *Client) joinDailyBasic(df *types.DataFrame, params BarParams, fields []string) error {
+	if params.AssetType != "" && params.AssetType != "E" {
+		return tsError.Wrapf(tsError.ErrInvalidParameter, "factors %v are only available for stocks", fields)
+	}
+
+	c.logger.Debug("正在获取每日指标, ts_code=%s, 字段: %v", params.TsCode, fields)
+
+	basic, err := c.GetDailyBasic(DailyBasicParams{
+		TSCode:    params.TsCode,
+		StartDate: barDate(params.StartDate),
+		EndDate:   barDate(params.EndDate),
+	}, append([]string{DailyBasicField.TradeDate}, fields...))
+	if err != nil {
+		c.logger.Error("获取每日指标失败: %v", err)
+		return err
+	}
+
+	df.LeftJoin(basic, []string{"trade_date"}, fields)
+	return nil
+}
+
+// containsField 判断字段列表是否包含指定字段
+func containsField(fields []string, field string) bool {
+	for _, f := range fields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
 // calculateVolumeRatio 计算量比
+//
+// 量比 = 当日成交量 / 前5日平均成交量；当日或前5日存在缺失值时结果为nil
 func (c *Client) calculateVolumeRatio(df *types.DataFrame) *types.DataFrame {
 	if len(df.Rows) < 6 {
 		// 数据不足，无法计算量比
 		return df
 	}
 
-	// 计算前5日平均成交量
-	for i := 5; i < len(df.Rows); i++ {
-		var sum float64
-		for j := 1; j <= 5; j++ {
-			vol := 0.0
-			if v, ok := df.Rows[i-j]["vol"].(float64); ok {
-				vol = v
-			} else if vStr, ok := df.Rows[i-j]["vol"].(string); ok {
-				if v, err := stringToFloat(vStr); err == nil {
-					vol = v
-				}
-			}
-			sum += vol
-		}
-
-		avgVol := sum / 5.0
+	// 前5日平均成交量
+	prevAvg := types.ShiftSeries(df.Rolling("vol", 5).Mean(), 1)
 
-		// 当日成交量
-		curVol := 0.0
-		if v, ok := df.Rows[i]["vol"].(float64); ok {
-			curVol = v
-		} else if vStr, ok := df.Rows[i]["vol"].(string); ok {
-			if v, err := stringToFloat(vStr); err == nil {
-				curVol = v
-			}
+	ratios := make([]interface{}, len(df.Rows))
+	for i, row := range df.Rows {
+		curVol, ok := types.ToFloat64(row["vol"])
+		if !ok {
+			continue
+		}
+		avgVol, ok := types.ToFloat64(prevAvg[i])
+		if !ok {
+			continue
 		}
 
 		// 计算量比
 		if avgVol > 0 {
-			df.Rows[i]["volume_ratio"] = curVol / avgVol
+			ratios[i] = curVol / avgVol
 		} else {
-			df.Rows[i]["volume_ratio"] = 0
+			ratios[i] = 0.0
 		}
 	}
 
-	return df
-}
-
-// calculateTurnoverRate 计算换手率
-func (c *Client) calculateTurnoverRate(df *types.DataFrame, tsCode string) *types.DataFrame {
-	// 此处需要获取股票的总股本数据来计算换手率
-	// 由于这需要额外的API调用，暂时不实现
-	// TODO: 实现换手率计算
-
+	df.SetColumn("volume_ratio", ratios)
 	return df
 }
@@ -0,0 +1,227 @@
+package client
+
+import (
+	"math"
+
+	tsError "github.com/Premium-Platform/go-tushare/pkg/errors"
+	"github.com/Premium-Platform/go-tushare/pkg/symbol"
+	"github.com/Premium-Platform/go-tushare/pkg/types"
+)
+
+const (
+	// LimitFromAPI 涨跌停价格取自stk_limit
+	LimitFromAPI = "api"
+	// LimitByRule 涨跌停价格按板块规则由昨收价计算
+	LimitByRule = "rule"
+)
+
+// chiNextReformDate 创业板注册制改革后涨跌幅限制调整为20%的首个交易日
+const chiNextReformDate = "20200824"
+
+// boardLimitPct 按板块规则获取涨跌幅限制比例
+//
+// 北交所30%；科创板20%；创业板2020年8月24日起20%，此前与主板相同；主板10%，ST股票5%
+func boardLimitPct(tsCode, tradeDate string, st bool) float64 {
+	sym, _ := symbol.Parse(tsCode)
+	switch {
+	case sym.Board == symbol.BoardBSE:
+		return 0.3
+	case sym.Board == symbol.BoardSTAR:
+		return 0.2
+	case sym.Board == symbol.BoardChiNext && tradeDate >= chiNextReformDate:
+		return 0.2
+	case st:
+		return 0.05
+	}
+	return 0.1
+}
+
+// limitPrice 计算涨跌停价格，四舍五入保留两位小数
+func limitPrice(preClose, pct float64) float64 {
+	return math.Round(preClose*(1+pct)*100+1e-6) / 100
+}
+
+// validateLimitParams 检查涨跌停、停牌标注参数
+func validateLimitParams(params BarParams) error {
+	if params.Limit == "" && !params.Suspend {
+		return nil
+	}
+	switch params.Limit {
+	case "", LimitFromAPI, LimitByRule:
+	default:
+		return tsError.Wrapf(tsError.ErrInvalidParameter, "unknown limit source %s", params.Limit)
+	}
+	if (params.AssetType != "" && params.AssetType != "E") || (params.Freq != "" && params.Freq != "D") {
+		return tsError.Wrap(tsError.ErrInvalidParameter, "limit and suspension annotations are only available for stock daily bars")
+	}
+	return nil
+}
+
+// annotateLimits 添加涨跌停价格、涨跌停标记和停牌标记（需在复权之前对原始价格调用）
+func (c *Client) annotateLimits(df *types.DataFrame, params BarParams) (*types.DataFrame, error) {
+	var err error
+
+	switch params.Limit {
+	case LimitFromAPI:
+		err = c.joinStkLimit(df, params)
+	case LimitByRule:
+		err = c.calculateLimits(df, params)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if params.Limit != "" {
+		upFlags := make([]interface{}, len(df.Rows))
+		downFlags := make([]interface{}, len(df.Rows))
+		for i, row := range df.Rows {
+			closePrice, ok := types.ToFloat64(row["close"])
+			if !ok {
+				continue
+			}
+			if up, ok := types.ToFloat64(row["up_limit"]); ok {
+				upFlags[i] = closePrice >= up
+			}
+			if down, ok := types.ToFloat64(row["down_limit"]); ok {
+				downFlags[i] = closePrice <= down
+			}
+		}
+		df.SetColumn("is_limit_up", upFlags)
+		df.SetColumn("is_limit_down", downFlags)
+	}
+
+	if params.Suspend {
+		if err = c.markSuspended(df, params); err != nil {
+			return nil, err
+		}
+	}
+
+	return df, nil
+}
+
+// joinStkLimit 按交易日期合并stk_limit的涨跌停价格
+func (c *Client) joinStkLimit(df *types.DataFrame, params BarParams) error {
+	c.logger.Debug("正在获取涨跌停价格, ts_code=%s", params.TsCode)
+
+	limits, err := c.GetStkLimit(StkLimitParams{
+		TSCode:    params.TsCode,
+		StartDate: barDate(params.StartDate),
+		EndDate:   barDate(params.EndDate),
+	}, []string{StkLimitField.TradeDate, StkLimitField.UpLimit, StkLimitField.DownLimit})
+	if err != nil {
+		c.logger.Error("获取涨跌停价格失败: %v", err)
+		return err
+	}
+
+	df.LeftJoin(limits, []string{"trade_date"}, []string{StkLimitField.UpLimit, StkLimitField.DownLimit})
+	return nil
+}
+
+// calculateLimits 按板块规则由昨收价计算涨跌停价格，ST状态取自namechange（与Security.IsSTAt一致）
+//
+// 规则不考虑新股上市初期不设涨跌幅限制的交易日，需要精确结果时使用stk_limit
+func (c *Client) calculateLimits(df *types.DataFrame, params BarParams) error {
+	sec, err := c.securityNames(params.TsCode)
+	if err != nil {
+		return err
+	}
+
+	ups := make([]interface{}, len(df.Rows))
+	downs := make([]interface{}, len(df.Rows))
+	sts := make([]interface{}, len(df.Rows))
+	for i, row := range df.Rows {
+		date, _ := row["trade_date"].(string)
+		st := sec.IsSTAt(date)
+		sts[i] = st
+
+		preClose, ok := types.ToFloat64(row["pre_close"])
+		if !ok || preClose <= 0 {
+			continue
+		}
+		pct := boardLimitPct(params.TsCode, date, st)
+		ups[i] = limitPrice(preClose, pct)
+		downs[i] = limitPrice(preClose, -pct)
+	}
+
+	df.SetColumn("up_limit", ups)
+	df.SetColumn("down_limit", downs)
+	df.SetColumn("is_st", sts)
+	return nil
+}
+
+// securityNames 从曾用名记录中获取股票的历史名称
+func (c *Client) securityNames(tsCode string) (*Security, error) {
+	c.logger.Debug("正在获取曾用名, ts_code=%s", tsCode)
+
+	names, err := c.GetNameChange(NameChangeParams{TsCode: tsCode},
+		[]string{NameChangeField.Name, NameChangeField.StartDate, NameChangeField.EndDate})
+	if err != nil {
+		c.logger.Error("获取曾用名失败: %v", err)
+		return nil, err
+	}
+
+	sec := &Security{TSCode: tsCode}
+	for _, row := range names.Rows {
+		sec.Names = append(sec.Names, namePeriod(row))
+	}
+	sortNames(sec.Names)
+	return sec, nil
+}
+
+// markSuspended 根据suspend_d标记全天停牌的交易日
+//
+// 全天停牌的交易日daily不返回数据，补充只有代码、日期和停牌标记的行，其余列为nil；
+// 日内临时停牌不视为停牌
+func (c *Client) markSuspended(df *types.DataFrame, params BarParams) error {
+	c.logger.Debug("正在获取停牌信息, ts_code=%s", params.TsCode)
+
+	suspend, err := c.GetSuspendD(SuspendDParams{
+		TSCode:      params.TsCode,
+		StartDate:   barDate(params.StartDate),
+		EndDate:     barDate(params.EndDate),
+		SuspendType: "S",
+	}, c.CommonSuspendDFields())
+	if err != nil {
+		c.logger.Error("获取停牌信息失败: %v", err)
+		return err
+	}
+
+	suspended := make(map[string]bool)
+	for _, row := range suspend.Rows {
+		date, _ := row[SuspendDField.TradeDate].(string)
+		timing, _ := row[SuspendDField.SuspendTiming].(string)
+		if date != "" && timing == "" {
+			suspended[date] = true
+		}
+	}
+
+	flags := make([]interface{}, len(df.Rows))
+	seen := make(map[string]bool, len(df.Rows))
+	for i, row := range df.Rows {
+		date, _ := row["trade_date"].(string)
+		seen[date] = true
+		flags[i] = suspended[date]
+	}
+	df.SetColumn("is_suspended", flags)
+
+	for _, col := range []string{"ts_code", "trade_date"} {
+		if !df.HasColumn(col) {
+			df.Columns = append(df.Columns, col)
+		}
+	}
+	for date := range suspended {
+		if seen[date] {
+			continue
+		}
+		row := make(map[string]interface{}, len(df.Columns))
+		for _, col := range df.Columns {
+			row[col] = nil
+		}
+		row["ts_code"] = params.TsCode
+		row["trade_date"] = date
+		row["is_suspended"] = true
+		df.Rows = append(df.Rows, row)
+	}
+
+	return nil
+}
@@ -0,0 +1,187 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	tsError "github.com/Premium-Platform/go-tushare/pkg/errors"
+)
+
+func TestBoardLimitPct(t *testing.T) {
+	tests := []struct {
+		code string
+		date string
+		st   bool
+		want float64
+	}{
+		{"600000.SH", "20240102", false, 0.1},
+		{"600000.SH", "20240102", true, 0.05},
+		{"000001.SZ", "20240102", false, 0.1},
+		{"300750.SZ", "20200821", false, 0.1}, // 创业板改革前与主板相同
+		{"300750.SZ", "20200821", true, 0.05},
+		{"300750.SZ", "20200824", false, 0.2},
+		{"300750.SZ", "20200824", true, 0.2}, // 改革后ST股票同样为20%
+		{"688981.SH", "20240102", false, 0.2},
+		{"688981.SH", "20240102", true, 0.2},
+		{"830799.BJ", "20240102", false, 0.3},
+		{"830799.BJ", "20240102", true, 0.3},
+	}
+	for _, tt := range tests {
+		if got := boardLimitPct(tt.code, tt.date, tt.st); got != tt.want {
+			t.Errorf("boardLimitPct(%s, %s, %v) = %v, want %v", tt.code, tt.date, tt.st, got, tt.want)
+		}
+	}
+}
+
+// TestLimitPrice 涨跌停价格四舍五入保留两位小数
+func TestLimitPrice(t *testing.T) {
+	tests := []struct {
+		preClose float64
+		pct      float64
+		want     float64
+	}{
+		{10.0, 0.1, 11.0},
+		{10.0, -0.1, 9.0},
+		{10.05, 0.1, 11.06},  // 11.055
+		{10.05, -0.1, 9.05},  // 9.045
+		{3.33, 0.05, 3.5},    // 3.4965
+		{3.33, -0.05, 3.16},  // 3.1635
+		{15.27, 0.2, 18.32},  // 18.324
+		{15.27, -0.2, 12.22}, // 12.216
+	}
+	for _, tt := range tests {
+		if got := limitPrice(tt.preClose, tt.pct); got != tt.want {
+			t.Errorf("limitPrice(%v, %v) = %v, want %v", tt.preClose, tt.pct, got, tt.want)
+		}
+	}
+}
+
+// newLimitServer 模拟daily、namechange和suspend_d接口
+//
+// 600000.SH在20240103起更名为ST浦发；20240104全天停牌（daily无数据），20240105日内临时停牌
+func newLimitServer(t *testing.T) *Client {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req RequestParams
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+		}
+
+		var fields []string
+		var items [][]interface{}
+		switch req.APIName {
+		case "daily":
+			fields = []string{"ts_code", "trade_date", "close", "pre_close"}
+			items = [][]interface{}{
+				{"600000.SH", "20240105", 9.98, 10.0},
+				{"600000.SH", "20240103", 10.0, 10.5},
+				{"600000.SH", "20240102", 11.55, 10.5},
+			}
+		case "namechange":
+			fields = []string{"name", "start_date", "end_date"}
+			items = [][]interface{}{
+				{"ST浦发", "20240103", nil},
+				{"浦发银行", "19991110", "20240102"},
+			}
+		case "suspend_d":
+			fields = []string{"ts_code", "trade_date", "suspend_timing", "suspend_type"}
+			items = [][]interface{}{
+				{"600000.SH", "20240104", nil, "S"},
+				{"600000.SH", "20240105", "09:30-10:30", "S"},
+			}
+		default:
+			t.Errorf("unexpected api %s", req.APIName)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"code": 0,
+			"data": map[string]interface{}{"fields": fields, "items": items},
+		})
+	}))
+	t.Cleanup(srv.Close)
+
+	c := New("token")
+	c.SetAPIURL(srv.URL)
+	return c
+}
+
+// TestBarLimitByRule 按板块规则计算涨跌停价格，ST状态与Security.IsSTAt一致
+func TestBarLimitByRule(t *testing.T) {
+	c := newLimitServer(t)
+	df, err := c.Bar(BarParams{TsCode: "600000.SH", StartDate: "20240102", EndDate: "20240105", Limit: LimitByRule})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sec, err := c.securityNames("600000.SH")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		date      string
+		st        bool
+		up, down  float64
+		limitUp   bool
+		limitDown bool
+	}{
+		{"20240102", false, 11.55, 9.45, true, false},
+		{"20240103", true, 11.03, 9.98, false, false},
+		{"20240105", true, 10.5, 9.5, false, false},
+	}
+	if len(df.Rows) != len(want) {
+		t.Fatalf("got %d rows, want %d", len(df.Rows), len(want))
+	}
+	for i, w := range want {
+		row := df.Rows[i]
+		if row["trade_date"] != w.date || row["is_st"] != w.st || row["up_limit"] != w.up || row["down_limit"] != w.down ||
+			row["is_limit_up"] != w.limitUp || row["is_limit_down"] != w.limitDown {
+			t.Errorf("row %d = %v, want %+v", i, row, w)
+		}
+		if sec.IsSTAt(w.date) != w.st {
+			t.Errorf("IsSTAt(%s) = %v, want %v", w.date, sec.IsSTAt(w.date), w.st)
+		}
+	}
+}
+
+// TestBarSuspend 全天停牌的交易日补充空行，日内临时停牌不视为停牌
+func TestBarSuspend(t *testing.T) {
+	c := newLimitServer(t)
+	df, err := c.Bar(BarParams{TsCode: "600000.SH", StartDate: "20240102", EndDate: "20240105", Suspend: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		date      string
+		suspended bool
+	}{
+		{"20240102", false},
+		{"20240103", false},
+		{"20240104", true},
+		{"20240105", false},
+	}
+	if len(df.Rows) != len(want) {
+		t.Fatalf("got %d rows, want %d", len(df.Rows), len(want))
+	}
+	for i, w := range want {
+		row := df.Rows[i]
+		if row["trade_date"] != w.date || row["is_suspended"] != w.suspended {
+			t.Errorf("row %d = %v, want %+v", i, row, w)
+		}
+	}
+	if row := df.Rows[2]; row["ts_code"] != "600000.SH" || row["close"] != nil {
+		t.Errorf("suspended row = %v, want ts_code and nil prices", row)
+	}
+
+	// 涨跌停和停牌标注只支持股票日线
+	for _, params := range []BarParams{
+		{TsCode: "600000.SH", Limit: "exchange"},
+		{TsCode: "600000.SH", Limit: LimitByRule, Freq: "W"},
+		{TsCode: "000300.SH", Suspend: true, AssetType: "I"},
+	} {
+		if _, err := c.Bar(params); tsError.Cause(err) != tsError.ErrInvalidParameter {
+			t.Errorf("Bar(%+v) error = %v, want ErrInvalidParameter", params, err)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package client
+
+import (
+	tsError "github.com/Premium-Platform/go-tushare/pkg/errors"
+	"github.com/Premium-Platform/go-tushare/pkg/types"
+)
+
+// marketBasicFields 全市场截面合并的每日指标字段
+var marketBasicFields = []string{
+	DailyBasicField.TurnoverRate,
+	DailyBasicField.TurnoverRateF,
+	DailyBasicField.VolumeRatio,
+	DailyBasicField.PE,
+	DailyBasicField.PETTM,
+	DailyBasicField.PB,
+	DailyBasicField.PS,
+	DailyBasicField.PSTTM,
+	DailyBasicField.DVRatio,
+	DailyBasicField.DVTTM,
+	DailyBasicField.TotalShare,
+	DailyBasicField.FloatShare,
+	DailyBasicField.FreeShare,
+	DailyBasicField.TotalMV,
+	DailyBasicField.CircMV,
+}
+
+// marketDaily 获取交易日的全市场日线（未复权），超过单次返回上限时分页获取
+func (c *Client) marketDaily(tradeDate string) (*types.DataFrame, error) {
+	return c.queryAll("daily", map[string]interface{}{"trade_date": tradeDate}, []string{})
+}
+
+// MarketBar 获取某个交易日全部股票的日线截面
+//
+// 按交易日获取daily、stk_limit和daily_basic并按股票代码合并，结果按股票代码升序排列。
+// 复权时价格（含涨跌停价）与Bar的规则一致：后复权乘以当日复权因子；前复权再除以该股票最新的复权因子，
+// 最新复权因子取自客户端维护的复权因子存储（见FactorStore），每天首次使用时自动更新。
+func (c *Client) MarketBar(tradeDate string, adjust string) (*types.DataFrame, error) {
+	if tradeDate == "" {
+		return nil, tsError.Wrap(tsError.ErrInvalidParameter, "trade date is required")
+	}
+	switch adjust {
+	case "", AdjustNone, AdjustQFQ, AdjustHFQ:
+	default:
+		return nil, tsError.Wrapf(tsError.ErrInvalidParameter, "unknown adjust type %s", adjust)
+	}
+
+	c.logger.Debug("正在获取全市场日线, trade_date=%s, adjust=%s", tradeDate, adjust)
+
+	df, err := c.marketDaily(tradeDate)
+	if err != nil {
+		c.logger.Error("获取全市场日线失败: %v", err)
+		return nil, err
+	}
+	if len(df.Rows) == 0 {
+		return df, nil
+	}
+
+	// 涨跌停价格
+	limits, err := c.GetStkLimit(StkLimitParams{TradeDate: tradeDate},
+		[]string{StkLimitField.TSCode, StkLimitField.UpLimit, StkLimitField.DownLimit})
+	if err != nil {
+		c.logger.Error("获取涨跌停价格失败: %v", err)
+		return nil, err
+	}
+	df.LeftJoin(limits, []string{"ts_code"}, []string{StkLimitField.UpLimit, StkLimitField.DownLimit})
+
+	// 复权
+	if adjust == AdjustQFQ || adjust == AdjustHFQ {
+		if df, err = c.adjustMarket(df, tradeDate, adjust); err != nil {
+			return nil, err
+		}
+	}
+
+	// 每日指标
+	basic, err := c.queryAll("daily_basic", map[string]interface{}{"trade_date": tradeDate},
+		append([]string{DailyBasicField.TSCode}, marketBasicFields...))
+	if err != nil {
+		c.logger.Error("获取每日指标失败: %v", err)
+		return nil, err
+	}
+	df.LeftJoin(basic, []string{"ts_code"}, marketBasicFields)
+
+	df.SortBy("ts_code", true)
+	return df, nil
+}
+
+// adjustMarket 使用当日复权因子和复权因子存储中的最新因子对全市场截面复权
+func (c *Client) adjustMarket(df *types.DataFrame, tradeDate string, adjust string) (*types.DataFrame, error) {
+	fcts, err := c.marketFactors(tradeDate)
+	if err != nil {
+		c.logger.Error("获取复权因子失败: %v", err)
+		return nil, err
+	}
+
+	factors := make(map[string]float64, len(fcts.Rows))
+	for _, row := range fcts.Rows {
+		code, _ := row[AdjFactorField.TSCode].(string)
+		if f, ok := types.ToFloat64(row[AdjFactorField.AdjFactor]); ok && f > 0 {
+			factors[code] = f
+		}
+	}
+
+	var latest map[string]float64
+	if adjust == AdjustQFQ {
+		codes := make([]string, 0, len(factors))
+		for code := range factors {
+			codes = append(codes, code)
+		}
+		if latest, err = c.latestFactorsFor(codes, tradeDate); err != nil {
+			return nil, err
+		}
+	}
+
+	missing := 0
+	for i, row := range df.Rows {
+		code, _ := row["ts_code"].(string)
+		ratio, ok := factors[code]
+		if !ok {
+			missing++
+			continue
+		}
+		if adjust == AdjustQFQ {
+			// 查询后仍没有最新复权因子的股票以当日因子为锚定因子
+			if anchor, ok := latest[code]; ok {
+				ratio = ratio / anchor
+			} else {
+				ratio = 1
+			}
+		}
+		df.Rows[i] = applyAdjustFactor(row, ratio, false, priceDecimals("E"))
+	}
+	if missing > 0 {
+		c.logger.Warn("%d只股票未找到复权因子, 使用未复权数据, trade_date=%s", missing, tradeDate)
+	}
+
+	return df, nil
+}
@@ -0,0 +1,111 @@
+package client
+
+import (
+	"strings"
+
+	tsError "github.com/Premium-Platform/go-tushare/pkg/errors"
+	"github.com/Premium-Platform/go-tushare/pkg/types"
+)
+
+// MinuteFreqs Bar接口支持的分钟频率
+var MinuteFreqs = []string{"1min", "5min", "15min", "30min", "60min"}
+
+// isMinuteFreq 判断是否为分钟频率
+func isMinuteFreq(freq string) bool {
+	for _, f := range MinuteFreqs {
+		if f == freq {
+			return true
+		}
+	}
+	return false
+}
+
+// minuteBar 分钟行情数据
+//
+// 股票和场内基金使用stk_mins，指数使用idx_mins，期货使用ft_mins；StartDate/EndDate可以是YYYYMMDD，
+// 也可以是YYYY-MM-DD HH:MM:SS格式的日期时间。股票和基金复权时使用交易日当天的复权因子。
+func (c *Client) minuteBar(params BarParams) (*types.DataFrame, error) {
+	var apiName string
+
+	switch params.AssetType {
+	case "E", "", "FD":
+		apiName = "stk_mins"
+	case "I":
+		apiName = "idx_mins"
+	case "FT":
+		apiName = "ft_mins"
+	default:
+		return nil, tsError.Wrapf(tsError.ErrInvalidParameter, "minute bars are not supported for asset type %s", params.AssetType)
+	}
+
+	// 构建请求参数
+	queryParams := map[string]interface{}{
+		"ts_code": params.TsCode,
+		"freq":    params.Freq,
+	}
+
+	if params.StartDate != "" {
+		queryParams["start_date"] = minuteDateTime(params.StartDate, false)
+	}
+
+	if params.EndDate != "" {
+		queryParams["end_date"] = minuteDateTime(params.EndDate, true)
+	}
+
+	// 获取行情数据
+	df, err := c.Query(apiName, queryParams, []string{})
+	if err != nil {
+		return nil, err
+	}
+
+	// 股票、基金和指数没有夜盘，交易日期即交易时间的日期部分
+	if params.AssetType != "FT" && df.HasColumn("trade_time") && !df.HasColumn("trade_date") {
+		dates := make([]interface{}, len(df.Rows))
+		for i, row := range df.Rows {
+			if t, ok := row["trade_time"].(string); ok {
+				dates[i] = datePart(t)
+			}
+		}
+		df.SetColumn("trade_date", dates)
+	}
+
+	// 如果需要复权处理（股票和场内基金）
+	if (params.AssetType == "E" || params.AssetType == "" || params.AssetType == "FD") && params.AdjustType != "" && params.AdjustType != AdjustNone {
+		df, err = c.adjustBar(df, params)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return df, nil
+}
+
+// minuteDateTime 将日期转换为分钟接口使用的日期时间格式
+//
+// YYYYMMDD格式的开始日期转换为当天00:00:00，结束日期转换为当天23:59:59；
+// 已包含时间的参数原样保留（YYYYMMDD HH:MM:SS会规范为YYYY-MM-DD HH:MM:SS）
+func minuteDateTime(s string, end bool) string {
+	s = strings.TrimSpace(s)
+	parts := strings.SplitN(s, " ", 2)
+	date := parts[0]
+	if len(date) == 8 && !strings.Contains(date, "-") {
+		date = date[:4] + "-" + date[4:6] + "-" + date[6:]
+	}
+
+	if len(parts) == 2 {
+		return date + " " + strings.TrimSpace(parts[1])
+	}
+	if end {
+		return date + " 23:59:59"
+	}
+	return date + " 00:00:00"
+}
+
+// datePart 获取日期或日期时间中的日期部分（YYYYMMDD）
+func datePart(s string) string {
+	s = strings.TrimSpace(s)
+	if idx := strings.Index(s, " "); idx >= 0 {
+		s = s[:idx]
+	}
+	return strings.ReplaceAll(s, "-", "")
+}
@@ -0,0 +1,286 @@
+package client
+
+import (
+	"sync"
+
+	tsError "github.com/Premium-Platform/go-tushare/pkg/errors"
+	"github.com/Premium-Platform/go-tushare/pkg/types"
+)
+
+const (
+	// MultiModeAuto 自动选择请求次数更少的获取方式
+	MultiModeAuto = "auto"
+	// MultiModeByCode 按证券逐个获取
+	MultiModeByCode = "by_code"
+	// MultiModeByDate 按交易日获取全市场数据后筛选（仅股票日线）
+	MultiModeByDate = "by_date"
+)
+
+// MultiBarResult 批量行情结果
+type MultiBarResult struct {
+	Data   *types.DataFrame // 全部证券的行情（长表），按证券代码顺序拼接，证券内按时间排序
+	Errors map[string]error // 获取失败或无法识别的证券代码（按交易日获取时为交易日期）及对应错误
+}
+
+// ByCode 按证券代码拆分行情数据
+func (r *MultiBarResult) ByCode() map[string]*types.DataFrame {
+	result := make(map[string]*types.DataFrame)
+	if r.Data == nil {
+		return result
+	}
+	for _, row := range r.Data.Rows {
+		code, _ := row["ts_code"].(string)
+		df, ok := result[code]
+		if !ok {
+			df = types.NewDataFrame(r.Data.Columns, nil)
+			result[code] = df
+		}
+		df.Rows = append(df.Rows, row)
+	}
+	return result
+}
+
+// BarMulti 批量获取多个证券的行情数据
+//
+// 按证券逐个获取时在客户端的并发数和频率限制下并发请求；股票日线在交易日数少于证券数时
+// 改为按交易日获取全市场数据后筛选，请求次数更少。params.TsCode被忽略，其余参数与Bar相同。
+// 证券代码规范化为TuShare格式，无法识别的代码与获取失败的证券一样记录在结果的Errors中（键为原始代码），
+// 全部代码都无法识别时返回ErrInvalidParameter；单个证券（或交易日）获取失败不会中断整体。
+func (c *Client) BarMulti(codes []string, params BarParams) (*MultiBarResult, error) {
+	if len(codes) == 0 {
+		return nil, tsError.Wrap(tsError.ErrInvalidParameter, "codes is required")
+	}
+	if err := normalizeBarDates(&params); err != nil {
+		return nil, err
+	}
+
+	normalized := make([]string, 0, len(codes))
+	invalid := make(map[string]error)
+	for _, code := range codes {
+		tsCode, err := normalizeBarCode(params.AssetType, code)
+		if err != nil {
+			c.logger.Warn("无法识别证券代码, ts_code=%s: %v", code, err)
+			invalid[code] = err
+			continue
+		}
+		normalized = append(normalized, tsCode)
+	}
+	if len(normalized) == 0 {
+		return nil, tsError.Wrapf(tsError.ErrInvalidParameter, "no valid codes in %v", codes)
+	}
+
+	result, err := c.barMulti(normalized, params)
+	if err != nil {
+		return nil, err
+	}
+	for code, err := range invalid {
+		result.Errors[code] = err
+	}
+	return result, nil
+}
+
+// barMulti 按获取方式批量获取已规范化的证券代码的行情
+func (c *Client) barMulti(codes []string, params BarParams) (*MultiBarResult, error) {
+	mode := params.MultiMode
+	if mode == "" {
+		mode = MultiModeAuto
+	}
+
+	canByDate := (params.AssetType == "" || params.AssetType == "E") &&
+		(params.Freq == "" || params.Freq == "D") &&
+		params.StartDate != "" && params.EndDate != "" &&
+		params.Limit == "" && !params.Suspend
+
+	switch mode {
+	case MultiModeByCode:
+		return c.barMultiByCode(codes, params), nil
+	case MultiModeByDate:
+		if !canByDate {
+			return nil, tsError.Wrap(tsError.ErrInvalidParameter, "by_date mode only supports stock daily bars with start and end dates and no limit or suspension annotations")
+		}
+		days, err := c.tradingDays(barDate(params.StartDate), barDate(params.EndDate))
+		if err != nil {
+			return nil, err
+		}
+		return c.barMultiByDate(codes, days, params)
+	case MultiModeAuto:
+		if canByDate {
+			days, err := c.tradingDays(barDate(params.StartDate), barDate(params.EndDate))
+			switch {
+			case tsError.Cause(err) == tsError.ErrOutOfRange:
+				// 日期超出交易日历范围时无法按交易日获取
+				c.logger.Debug("交易日历不覆盖查询区间, 按股票代码获取: %v", err)
+			case err != nil:
+				return nil, err
+			case len(days) < len(codes):
+				c.logger.Debug("交易日数(%d)少于证券数(%d), 按交易日获取", len(days), len(codes))
+				return c.barMultiByDate(codes, days, params)
+			}
+		}
+		return c.barMultiByCode(codes, params), nil
+	}
+
+	return nil, tsError.Wrapf(tsError.ErrInvalidParameter, "unknown multi mode %s", mode)
+}
+
+// parallel 在客户端的最大并发数下执行n个任务
+func (c *Client) parallel(n int, task func(i int)) {
+	sem := make(chan struct{}, c.maxConcurrency())
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			task(i)
+		}(i)
+	}
+	wg.Wait()
+}
+
+// barMultiByCode 按证券逐个并发获取
+func (c *Client) barMultiByCode(codes []string, params BarParams) *MultiBarResult {
+	frames := make([]*types.DataFrame, len(codes))
+	errs := make([]error, len(codes))
+
+	c.parallel(len(codes), func(i int) {
+		p := params
+		p.TsCode = codes[i]
+		frames[i], errs[i] = c.Bar(p)
+	})
+
+	result := &MultiBarResult{Errors: make(map[string]error)}
+	for i, code := range codes {
+		if errs[i] != nil {
+			c.logger.Warn("获取行情失败, ts_code=%s: %v", code, errs[i])
+			result.Errors[code] = errs[i]
+		}
+	}
+	result.Data = types.Concat(frames...)
+	return result
+}
+
+// barMultiByDate 按交易日获取全市场日线及复权因子后筛选
+func (c *Client) barMultiByDate(codes []string, days []string, params BarParams) (*MultiBarResult, error) {
+	wanted := make(map[string]bool, len(codes))
+	for _, code := range codes {
+		wanted[code] = true
+	}
+	adjust := params.AdjustType != "" && params.AdjustType != AdjustNone
+
+	dailies := make([]*types.DataFrame, len(days))
+	factorFrames := make([]*types.DataFrame, len(days))
+	errs := make([]error, len(days))
+
+	c.parallel(len(days), func(i int) {
+		dailies[i], errs[i] = c.marketDaily(days[i])
+		if errs[i] == nil && adjust {
+			factorFrames[i], errs[i] = c.marketFactors(days[i])
+		}
+	})
+
+	result := &MultiBarResult{Errors: make(map[string]error)}
+
+	// 按证券汇总行情和复权因子
+	columns := make([]string, 0)
+	rowsByCode := make(map[string][]map[string]interface{})
+	factorsByCode := make(map[string]*types.DataFrame)
+	for i, day := range days {
+		if errs[i] != nil {
+			c.logger.Warn("获取全市场行情失败, trade_date=%s: %v", day, errs[i])
+			result.Errors[day] = errs[i]
+			continue
+		}
+		for _, col := range dailies[i].Columns {
+			if !containsField(columns, col) {
+				columns = append(columns, col)
+			}
+		}
+		for _, row := range dailies[i].Rows {
+			if code, _ := row["ts_code"].(string); wanted[code] {
+				rowsByCode[code] = append(rowsByCode[code], row)
+			}
+		}
+		if factorFrames[i] == nil {
+			continue
+		}
+		for _, row := range factorFrames[i].Rows {
+			code, _ := row["ts_code"].(string)
+			if !wanted[code] {
+				continue
+			}
+			if factorsByCode[code] == nil {
+				factorsByCode[code] = types.NewDataFrame(factorFrames[i].Columns, nil)
+			}
+			factorsByCode[code].Rows = append(factorsByCode[code].Rows, row)
+		}
+	}
+
+	// 前复权锚定因子
+	var anchors map[string]float64
+	if adjust && params.AdjustType == AdjustQFQ {
+		var err error
+		anchors, err = c.anchorFactors(params.AdjustAnchor, codes, params.StartDate)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	frames := make([]*types.DataFrame, 0, len(codes))
+	for _, code := range codes {
+		rows, ok := rowsByCode[code]
+		if !ok {
+			continue
+		}
+		df := types.NewDataFrame(columns, rows)
+
+		if adjust {
+			if factors := parseFactors(factorsByCode[code]); len(factors) > 0 {
+				anchor, ok := anchors[code]
+				if !ok {
+					anchor = factors[len(factors)-1].factor
+				}
+				df = adjustRows(df, factors, params.AdjustType, anchor, params.AdjustVolume, priceDecimals(params.AssetType))
+			}
+		}
+
+		p := params
+		p.TsCode = code
+		df, err := c.finishBar(df, p)
+		if err != nil {
+			c.logger.Warn("处理行情失败, ts_code=%s: %v", code, err)
+			result.Errors[code] = err
+			continue
+		}
+		frames = append(frames, df)
+	}
+
+	result.Data = types.Concat(frames...)
+	return result, nil
+}
+
+// anchorFactors 获取全市场前复权锚定因子（锚定日期为空时取codes的最新因子，见latestFactorsFor）
+func (c *Client) anchorFactors(anchorDate string, codes []string, since string) (map[string]float64, error) {
+	if anchorDate == "" {
+		return c.latestFactorsFor(codes, since)
+	}
+
+	_, df, err := c.publishedFactors(anchorDate)
+	if err != nil || df == nil {
+		return nil, err
+	}
+	anchors := make(map[string]float64, len(df.Rows))
+	for _, row := range df.Rows {
+		code, _ := row["ts_code"].(string)
+		if f, ok := types.ToFloat64(row["adj_factor"]); ok && f > 0 {
+			anchors[code] = f
+		}
+	}
+	return anchors, nil
+}
+
+// tradingDays 获取区间内的交易日（升序）
+func (c *Client) tradingDays(startDate, endDate string) ([]string, error) {
+	return c.Calendar("SSE").TradingDaysBetween(startDate, endDate)
+}
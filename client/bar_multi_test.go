@@ -0,0 +1,217 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	tsError "github.com/Premium-Platform/go-tushare/pkg/errors"
+)
+
+// newBarMultiServer 模拟daily接口：按ts_code查询时返回该股票区间内的日线（降序），
+// 按trade_date查询时返回当日全市场日线；failCode的查询返回接口错误
+func newBarMultiServer(t *testing.T, days []string, market []string, failCode string) (*Client, *[]map[string]interface{}) {
+	t.Helper()
+	var mu sync.Mutex
+	var requests []map[string]interface{}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req RequestParams
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+		}
+		mu.Lock()
+		requests = append(requests, req.Params)
+		mu.Unlock()
+
+		if req.APIName != "daily" {
+			t.Errorf("unexpected api %s", req.APIName)
+		}
+		code, _ := req.Params["ts_code"].(string)
+		if code == failCode && code != "" {
+			w.Write([]byte(`{"code":40001,"msg":"no permission","data":null}`))
+			return
+		}
+
+		var items []string
+		if date, _ := req.Params["trade_date"].(string); date != "" {
+			for _, code := range market {
+				items = append(items, fmt.Sprintf(`["%s","%s",10.0]`, code, date))
+			}
+		} else {
+			for i := len(days) - 1; i >= 0; i-- {
+				items = append(items, fmt.Sprintf(`["%s","%s",10.0]`, code, days[i]))
+			}
+		}
+		fmt.Fprintf(w, `{"code":0,"msg":"","data":{"fields":["ts_code","trade_date","close"],"items":[%s]}}`,
+			strings.Join(items, ","))
+	}))
+	t.Cleanup(srv.Close)
+
+	c := New("token")
+	c.SetAPIURL(srv.URL)
+	c.SetOfflineCalendar(true)
+	return c, &requests
+}
+
+// barKeys 行情数据的“代码/日期”序列
+func barKeys(t *testing.T, result *MultiBarResult) []string {
+	t.Helper()
+	if result.Data == nil {
+		return nil
+	}
+	keys := make([]string, 0, len(result.Data.Rows))
+	for _, row := range result.Data.Rows {
+		keys = append(keys, fmt.Sprintf("%v/%v", row["ts_code"], row["trade_date"]))
+	}
+	return keys
+}
+
+// TestBarMultiByCode 按证券获取时结果按传入的代码顺序拼接、证券内按时间升序，失败的证券记录在Errors中
+func TestBarMultiByCode(t *testing.T) {
+	days := []string{"20240102", "20240103", "20240104", "20240105"}
+	c, requests := newBarMultiServer(t, days, nil, "000002.SZ")
+
+	result, err := c.BarMulti([]string{"600519.SH", "000002.SZ", "60051.SH", "000001.SZ"}, BarParams{
+		StartDate: "20240102",
+		EndDate:   "20240105",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 交易日数不少于证券数，自动选择按证券获取
+	if len(*requests) != 3 {
+		t.Errorf("got %d requests, want one per valid code", len(*requests))
+	}
+	for _, req := range *requests {
+		if req["ts_code"] == nil {
+			t.Errorf("request %v: want ts_code", req)
+		}
+	}
+
+	var want []string
+	for _, code := range []string{"600519.SH", "000001.SZ"} {
+		for _, day := range days {
+			want = append(want, code+"/"+day)
+		}
+	}
+	if got := barKeys(t, result); strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("rows = %v, want %v", got, want)
+	}
+
+	if len(result.Errors) != 2 {
+		t.Errorf("errors = %v, want 000002.SZ and 60051.SH", result.Errors)
+	}
+	if _, ok := result.Errors["000002.SZ"].(*tsError.APIError); !ok {
+		t.Errorf("000002.SZ error = %v, want API error", result.Errors["000002.SZ"])
+	}
+	if tsError.Cause(result.Errors["60051.SH"]) != tsError.ErrInvalidParameter {
+		t.Errorf("60051.SH error = %v, want ErrInvalidParameter", result.Errors["60051.SH"])
+	}
+
+	if by := result.ByCode(); len(by) != 2 || len(by["000001.SZ"].Rows) != len(days) {
+		t.Errorf("ByCode = %v, want 2 codes with %d rows each", by, len(days))
+	}
+
+	if _, err := c.BarMulti([]string{"60051.SH"}, BarParams{}); tsError.Cause(err) != tsError.ErrInvalidParameter {
+		t.Errorf("all codes invalid: error = %v, want ErrInvalidParameter", err)
+	}
+}
+
+// TestBarMultiAutoByDate 交易日数少于证券数时按交易日获取全市场日线后筛选
+func TestBarMultiAutoByDate(t *testing.T) {
+	codes := []string{"600519.SH", "000001.SZ", "000002.SZ"}
+	market := append([]string{"600000.SH"}, codes...)
+	c, requests := newBarMultiServer(t, nil, market, "")
+
+	result, err := c.BarMulti(codes, BarParams{StartDate: "20240105", EndDate: "20240108"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 20240105和20240108两个交易日，中间是周末
+	var dates []string
+	for _, req := range *requests {
+		if req["ts_code"] != nil {
+			t.Errorf("request %v: want no ts_code", req)
+		}
+		dates = append(dates, fmt.Sprint(req["trade_date"]))
+	}
+	if len(dates) != 2 {
+		t.Errorf("requested dates %v, want 20240105 and 20240108", dates)
+	}
+
+	var want []string
+	for _, code := range codes {
+		want = append(want, code+"/20240105", code+"/20240108")
+	}
+	if got := barKeys(t, result); strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("rows = %v, want %v", got, want)
+	}
+	if len(result.Errors) != 0 {
+		t.Errorf("errors = %v, want none", result.Errors)
+	}
+}
+
+func TestBarMultiModes(t *testing.T) {
+	days := []string{"20240105", "20240108"}
+	codes := []string{"600519.SH", "000001.SZ", "000002.SZ"}
+
+	// 指定按证券获取时不按交易日数切换
+	c, requests := newBarMultiServer(t, days, codes, "")
+	if _, err := c.BarMulti(codes, BarParams{StartDate: "20240105", EndDate: "20240108", MultiMode: MultiModeByCode}); err != nil {
+		t.Fatal(err)
+	}
+	if len(*requests) != len(codes) {
+		t.Errorf("by_code: got %d requests, want %d", len(*requests), len(codes))
+	}
+
+	// 超出交易日历范围时自动模式按证券获取
+	c, requests = newBarMultiServer(t, days, codes, "")
+	if _, err := c.BarMulti(codes, BarParams{StartDate: "20300102", EndDate: "20300103"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(*requests) != len(codes) {
+		t.Errorf("auto past horizon: got %d requests, want %d", len(*requests), len(codes))
+	}
+
+	// 按交易日获取只支持股票日线
+	for _, params := range []BarParams{
+		{StartDate: "20240105", EndDate: "20240108", Freq: "W"},
+		{StartDate: "20240105", EndDate: "20240108", Limit: "rule"},
+		{StartDate: "20240105"},
+	} {
+		params.MultiMode = MultiModeByDate
+		if _, err := c.BarMulti(codes, params); tsError.Cause(err) != tsError.ErrInvalidParameter {
+			t.Errorf("by_date %+v: error = %v, want ErrInvalidParameter", params, err)
+		}
+	}
+
+	if _, err := c.BarMulti(codes, BarParams{MultiMode: "by_week"}); tsError.Cause(err) != tsError.ErrInvalidParameter {
+		t.Errorf("unknown mode: error = %v, want ErrInvalidParameter", err)
+	}
+}
+
+// TestSetConcurrencyWhileFetching 并发获取期间修改频率限制和并发数不产生数据竞争
+func TestSetConcurrencyWhileFetching(t *testing.T) {
+	c, _ := newBarMultiServer(t, []string{"20240102"}, nil, "")
+	codes := []string{"600519.SH", "000001.SZ", "000002.SZ", "600000.SH"}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 20; i++ {
+			c.SetConcurrency(i%3 + 1)
+			c.SetRateLimit(i % 2 * 60000)
+		}
+	}()
+	if _, err := c.BarMulti(codes, BarParams{StartDate: "20240102", EndDate: "20240102", MultiMode: MultiModeByCode}); err != nil {
+		t.Fatal(err)
+	}
+	<-done
+}
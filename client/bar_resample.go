@@ -0,0 +1,127 @@
+package client
+
+import (
+	"fmt"
+	"time"
+
+	tsError "github.com/Premium-Platform/go-tushare/pkg/errors"
+	"github.com/Premium-Platform/go-tushare/pkg/resample"
+	"github.com/Premium-Platform/go-tushare/pkg/session"
+	"github.com/Premium-Platform/go-tushare/pkg/types"
+)
+
+// baseMinuteFreqs 可用作重采样基础数据的分钟频率（从大到小）
+var baseMinuteFreqs = []int{60, 30, 15, 5, 1}
+
+// isNativeFreq 判断是否为TuShare直接提供的频率
+//
+// 场内基金、期权、可转债只有日线接口，周线、月线在本地重采样
+func isNativeFreq(assetType, freq string) bool {
+	switch freq {
+	case "", "D":
+		return true
+	case "W", "M":
+		return assetType != "FD" && assetType != "O" && assetType != "CB"
+	}
+	return isMinuteFreq(freq)
+}
+
+// resampleBar 获取基础频率的行情数据并在本地重采样
+//
+// 日内周期使用能整除目标周期的最大分钟频率作为基础数据，日线以上周期使用日线，
+// 并按交易日历对齐周期、判断末尾周期是否完整。
+func (c *Client) resampleBar(params BarParams) (*types.DataFrame, error) {
+	rule, err := resample.ParseRule(params.Freq)
+	if err != nil {
+		return nil, err
+	}
+
+	// 选择基础频率
+	base := params
+	base.MA = nil
+	base.Factors = nil
+	base.Descending = false
+	if rule.Intraday() {
+		if params.AssetType != "" && params.AssetType != "E" && params.AssetType != "I" && params.AssetType != "FD" {
+			return nil, tsError.Wrapf(tsError.ErrInvalidParameter, "intraday resampling is not supported for asset type %s", params.AssetType)
+		}
+		for _, n := range baseMinuteFreqs {
+			if rule.N%n == 0 {
+				base.Freq = fmt.Sprintf("%dmin", n)
+				break
+			}
+		}
+	} else {
+		base.Freq = "D"
+	}
+
+	c.logger.Debug("本地重采样, 目标周期: %s, 基础周期: %s", params.Freq, base.Freq)
+
+	df, err := c.Bar(base)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := resample.Options{
+		Rule:    rule,
+		Partial: params.Partial,
+	}
+
+	// 日内周期按证券所在交易所的交易时段切分
+	if rule.Intraday() {
+		if sess, err := session.ForCode(params.TsCode); err == nil {
+			opts.Session = sess
+		}
+	}
+
+	// 日线以上周期需要交易日历
+	if !rule.Intraday() && df != nil && len(df.Rows) > 0 {
+		opts.TradingDays, err = c.resampleTradingDays(df, params, rule)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return resample.Resample(df, opts)
+}
+
+// resampleTradingDays 获取覆盖行情数据区间的交易日历
+//
+// 日历取到末尾周期结束后的第一个交易日（尚未发布时取到已发布的最后一天），用于判断末尾周期是否已经结束
+func (c *Client) resampleTradingDays(df *types.DataFrame, params BarParams, rule resample.Rule) ([]string, error) {
+	exchange := "SSE"
+	if params.AssetType == "FT" && params.Exchange != "" {
+		exchange = params.Exchange
+	}
+
+	first, _ := df.Rows[0]["trade_date"].(string)
+	last, _ := df.Rows[len(df.Rows)-1]["trade_date"].(string)
+	start, err := time.Parse("20060102", first)
+	if err != nil {
+		return nil, tsError.Wrapf(tsError.ErrInvalidParameter, "invalid trade_date %q", first)
+	}
+	end, err := time.Parse("20060102", last)
+	if err != nil {
+		return nil, tsError.Wrapf(tsError.ErrInvalidParameter, "invalid trade_date %q", last)
+	}
+
+	cal := c.Calendar(exchange)
+	var to string
+	if rule.Unit == resample.UnitDay {
+		// 交易日周期最迟在N个交易日后结束
+		to, err = cal.Offset(last, rule.N)
+	} else {
+		to, err = cal.NextTradingDay(rule.PeriodEnd(start, end).Format("20060102"))
+	}
+	if tsError.Cause(err) == tsError.ErrOutOfRange {
+		to, err = cal.Horizon(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if to < last {
+		to = last
+	}
+
+	return cal.TradingDaysBetween(first, to)
+}
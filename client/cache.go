@@ -0,0 +1,68 @@
+package client
+
+import (
+	"time"
+
+	"github.com/Premium-Platform/go-tushare/pkg/cache"
+)
+
+// SetCache 设置查询结果缓存，nil表示不使用缓存
+//
+// 例如使用文件系统缓存和默认策略：
+//
+//	store, err := cache.NewFileStore(".cache/tushare")
+//	cli.SetCache(cache.New(store, nil))
+func (c *Client) SetCache(qc *cache.Cache) {
+	c.cache = qc
+	if qc == nil {
+		c.logger.Info("已关闭查询缓存")
+		return
+	}
+	c.logger.Info("已开启查询缓存")
+}
+
+// Cache 获取查询结果缓存，未设置时为nil
+func (c *Client) Cache() *cache.Cache {
+	return c.cache
+}
+
+// CacheStats 获取缓存命中统计，未设置缓存时返回零值
+func (c *Client) CacheStats() cache.Stats {
+	if c.cache == nil {
+		return cache.Stats{}
+	}
+	return c.cache.Stats()
+}
+
+// SetMemoryCache 设置进程内缓存，capacity为最多保存的查询结果数，ttl<=0表示不过期；capacity<=0时关闭
+//
+// 进程内缓存在查询缓存之前生效，同时进行的相同查询（如多个goroutine获取trade_cal）只发出一次请求。
+// 结果的有效期取ttl和查询缓存策略（未设置查询缓存时为DefaultPolicy）中较短的一个，策略不缓存的接口不写入
+func (c *Client) SetMemoryCache(capacity int, ttl time.Duration) {
+	if capacity <= 0 {
+		c.memory = nil
+		c.logger.Info("已关闭进程内缓存")
+		return
+	}
+	c.memory, _ = cache.NewMemory(capacity, ttl)
+	c.logger.Info("进程内缓存已设置为最多 %d 条, 有效期 %v", capacity, ttl)
+}
+
+// defaultPolicy 未设置查询缓存时进程内缓存使用的策略
+var defaultPolicy = cache.DefaultPolicy()
+
+// cachePolicy 获取查询缓存的策略，未设置查询缓存时使用默认策略
+func (c *Client) cachePolicy() *cache.Policy {
+	if c.cache == nil {
+		return defaultPolicy
+	}
+	return c.cache.Policy()
+}
+
+// MemoryCacheStats 获取进程内缓存统计，未设置时返回零值
+func (c *Client) MemoryCacheStats() cache.MemoryStats {
+	if c.memory == nil {
+		return cache.MemoryStats{}
+	}
+	return c.memory.Stats()
+}
@@ -0,0 +1,114 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Premium-Platform/go-tushare/pkg/cache"
+	"github.com/Premium-Platform/go-tushare/pkg/types"
+)
+
+// TestMemoryCacheRespectsPolicy 进程内缓存不保存策略不缓存的接口，有效期不超过策略的有效期
+func TestMemoryCacheRespectsPolicy(t *testing.T) {
+	var requests []map[string]interface{}
+	c := newTestClient(t, &requests)
+	c.SetMemoryCache(100, time.Hour)
+
+	query := func(apiName string) {
+		t.Helper()
+		if _, err := c.Query(apiName, map[string]interface{}{}, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// 默认策略不缓存的接口每次都请求
+	query("top_list")
+	query("top_list")
+	if len(requests) != 2 {
+		t.Errorf("top_list: got %d requests, want 2", len(requests))
+	}
+
+	// 默认策略缓存的接口只请求一次
+	query("trade_cal")
+	query("trade_cal")
+	if len(requests) != 3 {
+		t.Errorf("trade_cal: got %d requests, want 3", len(requests))
+	}
+
+	// 设置了查询缓存时使用其策略
+	policy := cache.DefaultPolicy()
+	policy.Set("stock_basic", cache.Rule{TTL: 0})
+	store, err := cache.NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.SetCache(cache.New(store, policy))
+	query("stock_basic")
+	query("stock_basic")
+	if len(requests) != 5 {
+		t.Errorf("stock_basic: got %d requests, want 5", len(requests))
+	}
+}
+
+// TestQueryConcurrentShared 同时发起的相同查询只请求一次，命中进程内缓存时返回副本
+func TestQueryConcurrentShared(t *testing.T) {
+	var requests int32
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		<-release
+		w.Write([]byte(`{"code":0,"msg":"","data":{"fields":["cal_date","is_open"],"items":[["20240102",1]]}}`))
+	}))
+	defer srv.Close()
+
+	c := New("token")
+	c.SetAPIURL(srv.URL)
+	c.SetMemoryCache(100, time.Hour)
+
+	const n = 8
+	var wg sync.WaitGroup
+	frames := make([]*types.DataFrame, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			df, err := c.Query("trade_cal", map[string]interface{}{"exchange": "SSE"}, nil)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			frames[i] = df
+		}(i)
+	}
+	for c.MemoryCacheStats().Shared < n-1 {
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&requests); got != 1 {
+		t.Errorf("got %d requests, want 1", got)
+	}
+
+	// 每个调用方拿到独立的副本
+	frames[0].Rows[0]["cal_date"] = "changed"
+	for i := 1; i < n; i++ {
+		if frames[i].Rows[0]["cal_date"] != "20240102" {
+			t.Errorf("frame %d shares rows with frame 0", i)
+		}
+	}
+	df, err := c.Query("trade_cal", map[string]interface{}{"exchange": "SSE"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if df.Rows[0]["cal_date"] != "20240102" {
+		t.Errorf("cached frame was modified: %v", df.Rows[0])
+	}
+	if got := atomic.LoadInt32(&requests); got != 1 {
+		t.Errorf("cache hit made a request: got %d requests", got)
+	}
+}
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"github.com/Premium-Platform/go-tushare/pkg/calendar"
 	"github.com/Premium-Platform/go-tushare/pkg/types"
 )
 
@@ -50,11 +51,11 @@ func (c *Client) GetTradeCal(params TradeCalParams, fields []string) (*types.Dat
 	}
 
 	if params.StartDate != "" {
-		reqParams["start_date"] = params.StartDate
+		reqParams["start_date"] = types.Date(params.StartDate)
 	}
 
 	if params.EndDate != "" {
-		reqParams["end_date"] = params.EndDate
+		reqParams["end_date"] = types.Date(params.EndDate)
 	}
 
 	if params.IsOpen != "" {
@@ -111,3 +112,63 @@ func (c *Client) CommonTradeCalFields() []string {
 		TradeCalField.PretradeDate,
 	}
 }
+
+// Calendar 获取交易所的本地交易日历，exchange为空时使用上交所
+//
+// 同一交易所的日历在客户端内共享缓存
+func (c *Client) Calendar(exchange string) *calendar.Calendar {
+	if exchange == "" {
+		exchange = "SSE"
+	}
+
+	c.calendarMu.Lock()
+	defer c.calendarMu.Unlock()
+
+	cal, ok := c.calendars[exchange]
+	if !ok {
+		if c.offlineCal {
+			var err error
+			if cal, err = calendar.NewSnapshot(exchange); err != nil {
+				// 快照中没有的交易所，查询时返回错误
+				cal = calendar.New(exchange, calendar.SnapshotFetch)
+			}
+		} else {
+			cal = calendar.New(exchange, c.fetchCalendar)
+		}
+		c.calendars[exchange] = cal
+	}
+	return cal
+}
+
+// SetOfflineCalendar 设置是否使用内置的交易日历快照，不调用trade_cal接口
+//
+// 适用于单元测试和无法访问网络的环境，快照范围见calendar.SnapshotRange；切换时清空已缓存的日历
+func (c *Client) SetOfflineCalendar(offline bool) {
+	c.calendarMu.Lock()
+	defer c.calendarMu.Unlock()
+
+	c.offlineCal = offline
+	c.calendars = make(map[string]*calendar.Calendar)
+}
+
+// fetchCalendar 从trade_cal获取交易日历，作为本地交易日历的数据源
+func (c *Client) fetchCalendar(exchange, startDate, endDate string) ([]calendar.Day, error) {
+	c.logger.Debug("正在获取交易日历, exchange=%s, start_date=%s, end_date=%s", exchange, startDate, endDate)
+
+	df, err := c.GetTradeCal(TradeCalParams{
+		Exchange:  exchange,
+		StartDate: startDate,
+		EndDate:   endDate,
+	}, []string{TradeCalField.CalDate, TradeCalField.IsOpen})
+	if err != nil {
+		return nil, err
+	}
+
+	days := make([]calendar.Day, 0, len(df.Rows))
+	for _, row := range df.Rows {
+		date, _ := row[TradeCalField.CalDate].(string)
+		open, _ := types.ToFloat64(row[TradeCalField.IsOpen])
+		days = append(days, calendar.Day{Date: date, Open: open == 1})
+	}
+	return days, nil
+}
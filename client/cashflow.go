@@ -0,0 +1,439 @@
+package client
+
+import (
+	"github.com/Premium-Platform/go-tushare/pkg/types"
+)
+
+// CashflowParams 现金流量表查询参数
+type CashflowParams struct {
+	TSCode     string `json:"ts_code"`     // 股票代码
+	AnnDate    string `json:"ann_date"`    // 公告日期
+	FAnnDate   string `json:"f_ann_date"`  // 实际公告日期
+	StartDate  string `json:"start_date"`  // 公告开始日期
+	EndDate    string `json:"end_date"`    // 公告结束日期
+	Period     string `json:"period"`      // 报告期
+	ReportType string `json:"report_type"` // 报告类型
+	CompType   string `json:"comp_type"`   // 公司类型
+	IsCalc     string `json:"is_calc"`     // 是否计算报表：1是 0否
+}
+
+// CashflowField 现金流量表字段常量
+var CashflowField = struct {
+	TSCode                  string
+	AnnDate                 string
+	FAnnDate                string
+	EndDate                 string
+	CompType                string
+	ReportType              string
+	EndType                 string
+	NetProfit               string
+	FinanExp                string
+	CFrSaleSg               string
+	RecpTaxRends            string
+	NDeposIncrFi            string
+	NIncrLoansCb            string
+	NIncBorrOthFi           string
+	PremFrOrigContr         string
+	NIncrInsuredDep         string
+	NReinsurPrem            string
+	NIncrDispTfa            string
+	IfcCashIncr             string
+	NIncrDispFaas           string
+	NIncrLoansOthBank       string
+	NCapIncrRepur           string
+	CFrOthOperateA          string
+	CInfFrOperateA          string
+	CPaidGoodsS             string
+	CPaidToForEmpl          string
+	CPaidForTaxes           string
+	NIncrCltLoanAdv         string
+	NIncrDepCbob            string
+	CPayClaimsOrigInco      string
+	PayHandlingChrg         string
+	PayCommInsurPlcy        string
+	OthCashPayOperAct       string
+	StCashOutAct            string
+	NCashflowAct            string
+	OthRecpRalInvAct        string
+	CDispWithdrwlInvest     string
+	CRecpReturnInvest       string
+	NRecpDispFiolta         string
+	NRecpDispSobu           string
+	StotInflowsInvAct       string
+	CPayAcqConstFiolta      string
+	CPaidInvest             string
+	NDispSubsOthBiz         string
+	OthPayRalInvAct         string
+	NIncrPledgeLoan         string
+	StotOutInvAct           string
+	NCashflowInvAct         string
+	CRecpBorrow             string
+	ProcIssueBonds          string
+	OthCashRecpRalFncAct    string
+	StotCashInFncAct        string
+	FreeCashflow            string
+	CPrepayAmtBorr          string
+	CPayDistDpcpIntExp      string
+	InclDvdProfitPaidScMs   string
+	OthCashpayRalFncAct     string
+	StotCashoutFncAct       string
+	NCashFlowsFncAct        string
+	EffFxFluCash            string
+	NIncrCashCashEqu        string
+	CCashEquBegPeriod       string
+	CCashEquEndPeriod       string
+	CRecpCapContrib         string
+	InclCashRecSaims        string
+	UnconInvestLoss         string
+	ProvDeprAssets          string
+	DeprFaCogaDpba          string
+	AmortIntangAssets       string
+	LtAmortDeferredExp      string
+	DecrDeferredExp         string
+	IncrAccExp              string
+	LossDispFiolta          string
+	LossScrFa               string
+	LossFvChg               string
+	InvestLoss              string
+	DecrDefIncTaxAssets     string
+	IncrDefIncTaxLiab       string
+	DecrInventories         string
+	DecrOperPayable         string
+	IncrOperPayable         string
+	Others                  string
+	ImNetCashflowOperAct    string
+	ConvDebtIntoCap         string
+	ConvCopbondsDueWithin1y string
+	FaFncLeases             string
+	ImNIncrCashEqu          string
+	NetDismCapitalAdd       string
+	NetCashReceSec          string
+	CreditImpaLoss          string
+	UseRightAssetDep        string
+	OthLossAsset            string
+	EndBalCash              string
+	BegBalCash              string
+	EndBalCashEqu           string
+	BegBalCashEqu           string
+	UpdateFlag              string
+}{
+	TSCode:                  "ts_code",                     // TS股票代码
+	AnnDate:                 "ann_date",                    // 公告日期
+	FAnnDate:                "f_ann_date",                  // 实际公告日期
+	EndDate:                 "end_date",                    // 报告期
+	CompType:                "comp_type",                   // 公司类型
+	ReportType:              "report_type",                 // 报表类型
+	EndType:                 "end_type",                    // 报告期类型
+	NetProfit:               "net_profit",                  // 净利润
+	FinanExp:                "finan_exp",                   // 财务费用
+	CFrSaleSg:               "c_fr_sale_sg",                // 销售商品、提供劳务收到的现金
+	RecpTaxRends:            "recp_tax_rends",              // 收到的税费返还
+	NDeposIncrFi:            "n_depos_incr_fi",             // 客户存款和同业存放款项净增加额
+	NIncrLoansCb:            "n_incr_loans_cb",             // 向中央银行借款净增加额
+	NIncBorrOthFi:           "n_inc_borr_oth_fi",           // 向其他金融机构拆入资金净增加额
+	PremFrOrigContr:         "prem_fr_orig_contr",          // 收到原保险合同保费取得的现金
+	NIncrInsuredDep:         "n_incr_insured_dep",          // 保户储金净增加额
+	NReinsurPrem:            "n_reinsur_prem",              // 收到再保业务现金净额
+	NIncrDispTfa:            "n_incr_disp_tfa",             // 处置交易性金融资产净增加额
+	IfcCashIncr:             "ifc_cash_incr",               // 收取利息和手续费净增加额
+	NIncrDispFaas:           "n_incr_disp_faas",            // 处置可供出售金融资产净增加额
+	NIncrLoansOthBank:       "n_incr_loans_oth_bank",       // 拆入资金净增加额
+	NCapIncrRepur:           "n_cap_incr_repur",            // 回购业务资金净增加额
+	CFrOthOperateA:          "c_fr_oth_operate_a",          // 收到其他与经营活动有关的现金
+	CInfFrOperateA:          "c_inf_fr_operate_a",          // 经营活动现金流入小计
+	CPaidGoodsS:             "c_paid_goods_s",              // 购买商品、接受劳务支付的现金
+	CPaidToForEmpl:          "c_paid_to_for_empl",          // 支付给职工以及为职工支付的现金
+	CPaidForTaxes:           "c_paid_for_taxes",            // 支付的各项税费
+	NIncrCltLoanAdv:         "n_incr_clt_loan_adv",         // 客户贷款及垫款净增加额
+	NIncrDepCbob:            "n_incr_dep_cbob",             // 存放央行和同业款项净增加额
+	CPayClaimsOrigInco:      "c_pay_claims_orig_inco",      // 支付原保险合同赔付款项的现金
+	PayHandlingChrg:         "pay_handling_chrg",           // 支付手续费的现金
+	PayCommInsurPlcy:        "pay_comm_insur_plcy",         // 支付保单红利的现金
+	OthCashPayOperAct:       "oth_cash_pay_oper_act",       // 支付其他与经营活动有关的现金
+	StCashOutAct:            "st_cash_out_act",             // 经营活动现金流出小计
+	NCashflowAct:            "n_cashflow_act",              // 经营活动产生的现金流量净额
+	OthRecpRalInvAct:        "oth_recp_ral_inv_act",        // 收到其他与投资活动有关的现金
+	CDispWithdrwlInvest:     "c_disp_withdrwl_invest",      // 收回投资收到的现金
+	CRecpReturnInvest:       "c_recp_return_invest",        // 取得投资收益收到的现金
+	NRecpDispFiolta:         "n_recp_disp_fiolta",          // 处置固定资产、无形资产和其他长期资产收回的现金净额
+	NRecpDispSobu:           "n_recp_disp_sobu",            // 处置子公司及其他营业单位收到的现金净额
+	StotInflowsInvAct:       "stot_inflows_inv_act",        // 投资活动现金流入小计
+	CPayAcqConstFiolta:      "c_pay_acq_const_fiolta",      // 购建固定资产、无形资产和其他长期资产支付的现金
+	CPaidInvest:             "c_paid_invest",               // 投资支付的现金
+	NDispSubsOthBiz:         "n_disp_subs_oth_biz",         // 取得子公司及其他营业单位支付的现金净额
+	OthPayRalInvAct:         "oth_pay_ral_inv_act",         // 支付其他与投资活动有关的现金
+	NIncrPledgeLoan:         "n_incr_pledge_loan",          // 质押贷款净增加额
+	StotOutInvAct:           "stot_out_inv_act",            // 投资活动现金流出小计
+	NCashflowInvAct:         "n_cashflow_inv_act",          // 投资活动产生的现金流量净额
+	CRecpBorrow:             "c_recp_borrow",               // 取得借款收到的现金
+	ProcIssueBonds:          "proc_issue_bonds",            // 发行债券收到的现金
+	OthCashRecpRalFncAct:    "oth_cash_recp_ral_fnc_act",   // 收到其他与筹资活动有关的现金
+	StotCashInFncAct:        "stot_cash_in_fnc_act",        // 筹资活动现金流入小计
+	FreeCashflow:            "free_cashflow",               // 企业自由现金流量
+	CPrepayAmtBorr:          "c_prepay_amt_borr",           // 偿还债务支付的现金
+	CPayDistDpcpIntExp:      "c_pay_dist_dpcp_int_exp",     // 分配股利、利润或偿付利息支付的现金
+	InclDvdProfitPaidScMs:   "incl_dvd_profit_paid_sc_ms",  // 其中：子公司支付给少数股东的股利、利润
+	OthCashpayRalFncAct:     "oth_cashpay_ral_fnc_act",     // 支付其他与筹资活动有关的现金
+	StotCashoutFncAct:       "stot_cashout_fnc_act",        // 筹资活动现金流出小计
+	NCashFlowsFncAct:        "n_cash_flows_fnc_act",        // 筹资活动产生的现金流量净额
+	EffFxFluCash:            "eff_fx_flu_cash",             // 汇率变动对现金的影响
+	NIncrCashCashEqu:        "n_incr_cash_cash_equ",        // 现金及现金等价物净增加额
+	CCashEquBegPeriod:       "c_cash_equ_beg_period",       // 期初现金及现金等价物余额
+	CCashEquEndPeriod:       "c_cash_equ_end_period",       // 期末现金及现金等价物余额
+	CRecpCapContrib:         "c_recp_cap_contrib",          // 吸收投资收到的现金
+	InclCashRecSaims:        "incl_cash_rec_saims",         // 其中：子公司吸收少数股东投资收到的现金
+	UnconInvestLoss:         "uncon_invest_loss",           // 未确认投资损失
+	ProvDeprAssets:          "prov_depr_assets",            // 加：资产减值准备
+	DeprFaCogaDpba:          "depr_fa_coga_dpba",           // 固定资产折旧、油气资产折耗、生产性生物资产折旧
+	AmortIntangAssets:       "amort_intang_assets",         // 无形资产摊销
+	LtAmortDeferredExp:      "lt_amort_deferred_exp",       // 长期待摊费用摊销
+	DecrDeferredExp:         "decr_deferred_exp",           // 待摊费用减少
+	IncrAccExp:              "incr_acc_exp",                // 预提费用增加
+	LossDispFiolta:          "loss_disp_fiolta",            // 处置固定、无形资产和其他长期资产的损失
+	LossScrFa:               "loss_scr_fa",                 // 固定资产报废损失
+	LossFvChg:               "loss_fv_chg",                 // 公允价值变动损失
+	InvestLoss:              "invest_loss",                 // 投资损失
+	DecrDefIncTaxAssets:     "decr_def_inc_tax_assets",     // 递延所得税资产减少
+	IncrDefIncTaxLiab:       "incr_def_inc_tax_liab",       // 递延所得税负债增加
+	DecrInventories:         "decr_inventories",            // 存货的减少
+	DecrOperPayable:         "decr_oper_payable",           // 经营性应收项目的减少
+	IncrOperPayable:         "incr_oper_payable",           // 经营性应付项目的增加
+	Others:                  "others",                      // 其他
+	ImNetCashflowOperAct:    "im_net_cashflow_oper_act",    // 经营活动产生的现金流量净额(间接法)
+	ConvDebtIntoCap:         "conv_debt_into_cap",          // 债务转为资本
+	ConvCopbondsDueWithin1y: "conv_copbonds_due_within_1y", // 一年内到期的可转换公司债券
+	FaFncLeases:             "fa_fnc_leases",               // 融资租入固定资产
+	ImNIncrCashEqu:          "im_n_incr_cash_equ",          // 现金及现金等价物净增加额(间接法)
+	NetDismCapitalAdd:       "net_dism_capital_add",        // 拆出资金净增加额
+	NetCashReceSec:          "net_cash_rece_sec",           // 代理买卖证券收到的现金净额(元)
+	CreditImpaLoss:          "credit_impa_loss",            // 信用减值损失
+	UseRightAssetDep:        "use_right_asset_dep",         // 使用权资产折旧
+	OthLossAsset:            "oth_loss_asset",              // 其他资产减值损失
+	EndBalCash:              "end_bal_cash",                // 现金的期末余额
+	BegBalCash:              "beg_bal_cash",                // 减：现金的期初余额
+	EndBalCashEqu:           "end_bal_cash_equ",            // 加：现金等价物的期末余额
+	BegBalCashEqu:           "beg_bal_cash_equ",            // 减：现金等价物的期初余额
+	UpdateFlag:              "update_flag",                 // 更新标识
+}
+
+// GetCashflow 获取现金流量表
+//
+// 接口参数：
+// - ts_code: 股票代码
+// - ann_date: 公告日期
+// - f_ann_date: 实际公告日期
+// - start_date: 公告开始日期
+// - end_date: 公告结束日期
+// - period: 报告期
+// - report_type: 报告类型  1合并报表 2单季合并 3调整单季合并表 4调整合并报表 5调整前合并报表 6母公司报表 7母公司单季表 8 母公司调整单季表 9母公司调整表 10母公司调整前报表 11调整前合并报表 12母公司调整前报表
+// - comp_type: 公司类型  1一般工商业 2银行 3保险 4证券
+// - is_calc: 是否计算报表  1是 0否
+//
+// 返回字段：
+// - ts_code: TS股票代码
+// - ann_date: 公告日期
+// - f_ann_date: 实际公告日期
+// - end_date: 报告期
+// - comp_type: 公司类型
+// - report_type: 报表类型
+// - end_type: 报告期类型
+// - net_profit: 净利润
+// - finan_exp: 财务费用
+// - c_fr_sale_sg: 销售商品、提供劳务收到的现金
+// - recp_tax_rends: 收到的税费返还
+// - n_depos_incr_fi: 客户存款和同业存放款项净增加额
+// - n_incr_loans_cb: 向中央银行借款净增加额
+// - n_inc_borr_oth_fi: 向其他金融机构拆入资金净增加额
+// - prem_fr_orig_contr: 收到原保险合同保费取得的现金
+// - n_incr_insured_dep: 保户储金净增加额
+// - n_reinsur_prem: 收到再保业务现金净额
+// - n_incr_disp_tfa: 处置交易性金融资产净增加额
+// - ifc_cash_incr: 收取利息和手续费净增加额
+// - n_incr_disp_faas: 处置可供出售金融资产净增加额
+// - n_incr_loans_oth_bank: 拆入资金净增加额
+// - n_cap_incr_repur: 回购业务资金净增加额
+// - c_fr_oth_operate_a: 收到其他与经营活动有关的现金
+// - c_inf_fr_operate_a: 经营活动现金流入小计
+// - c_paid_goods_s: 购买商品、接受劳务支付的现金
+// - c_paid_to_for_empl: 支付给职工以及为职工支付的现金
+// - c_paid_for_taxes: 支付的各项税费
+// - n_incr_clt_loan_adv: 客户贷款及垫款净增加额
+// - n_incr_dep_cbob: 存放央行和同业款项净增加额
+// - c_pay_claims_orig_inco: 支付原保险合同赔付款项的现金
+// - pay_handling_chrg: 支付手续费的现金
+// - pay_comm_insur_plcy: 支付保单红利的现金
+// - oth_cash_pay_oper_act: 支付其他与经营活动有关的现金
+// - st_cash_out_act: 经营活动现金流出小计
+// - n_cashflow_act: 经营活动产生的现金流量净额
+// - oth_recp_ral_inv_act: 收到其他与投资活动有关的现金
+// - c_disp_withdrwl_invest: 收回投资收到的现金
+// - c_recp_return_invest: 取得投资收益收到的现金
+// - n_recp_disp_fiolta: 处置固定资产、无形资产和其他长期资产收回的现金净额
+// - n_recp_disp_sobu: 处置子公司及其他营业单位收到的现金净额
+// - stot_inflows_inv_act: 投资活动现金流入小计
+// - c_pay_acq_const_fiolta: 购建固定资产、无形资产和其他长期资产支付的现金
+// - c_paid_invest: 投资支付的现金
+// - n_disp_subs_oth_biz: 取得子公司及其他营业单位支付的现金净额
+// - oth_pay_ral_inv_act: 支付其他与投资活动有关的现金
+// - n_incr_pledge_loan: 质押贷款净增加额
+// - stot_out_inv_act: 投资活动现金流出小计
+// - n_cashflow_inv_act: 投资活动产生的现金流量净额
+// - c_recp_borrow: 取得借款收到的现金
+// - proc_issue_bonds: 发行债券收到的现金
+// - oth_cash_recp_ral_fnc_act: 收到其他与筹资活动有关的现金
+// - stot_cash_in_fnc_act: 筹资活动现金流入小计
+// - free_cashflow: 企业自由现金流量
+// - c_prepay_amt_borr: 偿还债务支付的现金
+// - c_pay_dist_dpcp_int_exp: 分配股利、利润或偿付利息支付的现金
+// - incl_dvd_profit_paid_sc_ms: 其中：子公司支付给少数股东的股利、利润
+// - oth_cashpay_ral_fnc_act: 支付其他与筹资活动有关的现金
+// - stot_cashout_fnc_act: 筹资活动现金流出小计
+// - n_cash_flows_fnc_act: 筹资活动产生的现金流量净额
+// - eff_fx_flu_cash: 汇率变动对现金的影响
+// - n_incr_cash_cash_equ: 现金及现金等价物净增加额
+// - c_cash_equ_beg_period: 期初现金及现金等价物余额
+// - c_cash_equ_end_period: 期末现金及现金等价物余额
+// - c_recp_cap_contrib: 吸收投资收到的现金
+// - incl_cash_rec_saims: 其中：子公司吸收少数股东投资收到的现金
+// - uncon_invest_loss: 未确认投资损失
+// - prov_depr_assets: 加：资产减值准备
+// - depr_fa_coga_dpba: 固定资产折旧、油气资产折耗、生产性生物资产折旧
+// - amort_intang_assets: 无形资产摊销
+// - lt_amort_deferred_exp: 长期待摊费用摊销
+// - decr_deferred_exp: 待摊费用减少
+// - incr_acc_exp: 预提费用增加
+// - loss_disp_fiolta: 处置固定、无形资产和其他长期资产的损失
+// - loss_scr_fa: 固定资产报废损失
+// - loss_fv_chg: 公允价值变动损失
+// - invest_loss: 投资损失
+// - decr_def_inc_tax_assets: 递延所得税资产减少
+// - incr_def_inc_tax_liab: 递延所得税负债增加
+// - decr_inventories: 存货的减少
+// - decr_oper_payable: 经营性应收项目的减少
+// - incr_oper_payable: 经营性应付项目的增加
+// - others: 其他
+// - im_net_cashflow_oper_act: 经营活动产生的现金流量净额(间接法)
+// - conv_debt_into_cap: 债务转为资本
+// - conv_copbonds_due_within_1y: 一年内到期的可转换公司债券
+// - fa_fnc_leases: 融资租入固定资产
+// - im_n_incr_cash_equ: 现金及现金等价物净增加额(间接法)
+// - net_dism_capital_add: 拆出资金净增加额
+// - net_cash_rece_sec: 代理买卖证券收到的现金净额(元)
+// - credit_impa_loss: 信用减值损失
+// - use_right_asset_dep: 使用权资产折旧
+// - oth_loss_asset: 其他资产减值损失
+// - end_bal_cash: 现金的期末余额
+// - beg_bal_cash: 减：现金的期初余额
+// - end_bal_cash_equ: 加：现金等价物的期末余额
+// - beg_bal_cash_equ: 减：现金等价物的期初余额
+// - update_flag: 更新标识
+func (c *Client) GetCashflow(params CashflowParams, fields []string) (*types.DataFrame, error) {
+	// 调用通用查询接口
+	return c.Query("cashflow", cashflowParams(params), fields)
+}
+
+// GetCashflowVIP 获取全部公司的现金流量表（cashflow_vip，需要5000积分）
+//
+// 参数和返回字段与GetCashflow相同，不指定股票代码时按报告期或公告日期获取全市场数据
+func (c *Client) GetCashflowVIP(params CashflowParams, fields []string) (*types.DataFrame, error) {
+	// 调用通用查询接口
+	return c.Query("cashflow_vip", cashflowParams(params), fields)
+}
+
+// cashflowParams 构建现金流量表请求参数
+func cashflowParams(params CashflowParams) map[string]interface{} {
+	reqParams := map[string]interface{}{}
+
+	if params.TSCode != "" {
+		reqParams["ts_code"] = params.TSCode
+	}
+
+	if params.AnnDate != "" {
+		reqParams["ann_date"] = types.Date(params.AnnDate)
+	}
+
+	if params.FAnnDate != "" {
+		reqParams["f_ann_date"] = types.Date(params.FAnnDate)
+	}
+
+	if params.StartDate != "" {
+		reqParams["start_date"] = types.Date(params.StartDate)
+	}
+
+	if params.EndDate != "" {
+		reqParams["end_date"] = types.Date(params.EndDate)
+	}
+
+	if params.Period != "" {
+		reqParams["period"] = types.Period(params.Period)
+	}
+
+	if params.ReportType != "" {
+		reqParams["report_type"] = params.ReportType
+	}
+
+	if params.CompType != "" {
+		reqParams["comp_type"] = params.CompType
+	}
+
+	if params.IsCalc != "" {
+		reqParams["is_calc"] = params.IsCalc
+	}
+
+	return reqParams
+}
+
+// GetLatestCashflow 获取最新的现金流量表数据（简化接口）
+func (c *Client) GetLatestCashflow(tsCode string) (*types.DataFrame, error) {
+	return c.GetCashflow(CashflowParams{
+		TSCode:     tsCode,
+		ReportType: "1", // 默认获取合并报表
+	}, nil)
+}
+
+// GetYearCashflow 获取年度现金流量表数据（简化接口）
+func (c *Client) GetYearCashflow(tsCode string, year string) (*types.DataFrame, error) {
+	period, err := annualPeriod(year)
+	if err != nil {
+		return nil, err
+	}
+	return c.GetCashflow(CashflowParams{
+		TSCode:     tsCode,
+		Period:     period, // 年度报表日期
+		ReportType: "1",    // 合并报表
+	}, nil)
+}
+
+// GetQuarterCashflow 获取季度现金流量表数据（简化接口）
+func (c *Client) GetQuarterCashflow(tsCode string, yearQuarter string) (*types.DataFrame, error) {
+	return c.GetCashflow(CashflowParams{
+		TSCode:     tsCode,
+		Period:     yearQuarter, // 如"20211231", "20220331"
+		ReportType: "1",         // 合并报表
+	}, nil)
+}
+
+// GetPeriodCashflow 获取某一报告期全部公司的现金流量表（简化接口，使用cashflow_vip，自动分页）
+func (c *Client) GetPeriodCashflow(period string) (*types.DataFrame, error) {
+	return c.queryAll("cashflow_vip", cashflowParams(CashflowParams{
+		Period:     period,
+		ReportType: "1", // 合并报表
+	}), []string{})
+}
+
+// CommonCashflowFields 返回常用的现金流量表字段列表
+func (c *Client) CommonCashflowFields() []string {
+	return []string{
+		CashflowField.TSCode,
+		CashflowField.EndDate,
+		CashflowField.AnnDate,
+		CashflowField.NetProfit,
+		CashflowField.CFrSaleSg,
+		CashflowField.NCashflowAct,
+		CashflowField.NCashflowInvAct,
+		CashflowField.NCashFlowsFncAct,
+		CashflowField.FreeCashflow,
+		CashflowField.NIncrCashCashEqu,
+		CashflowField.CCashEquEndPeriod,
+	}
+}
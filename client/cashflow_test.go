@@ -0,0 +1,90 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	tsError "github.com/Premium-Platform/go-tushare/pkg/errors"
+)
+
+// TestGetPeriodCashflow 报告期规范为YYYYMMDD，按queryPageSize分页获取全部数据
+func TestGetPeriodCashflow(t *testing.T) {
+	var requests []RequestParams
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req RequestParams
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+		}
+		requests = append(requests, req)
+
+		// 第一页返回满页，第二页返回3行
+		n := 3
+		if offset, _ := req.Params["offset"].(float64); offset == 0 {
+			n = queryPageSize
+		}
+		items := make([][]interface{}, n)
+		for i := range items {
+			items[i] = []interface{}{"000001.SZ", "20240630", float64(i)}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"code": 0,
+			"data": map[string]interface{}{"fields": []string{"ts_code", "end_date", "n_cashflow_act"}, "items": items},
+		})
+	}))
+	defer srv.Close()
+
+	c := New("token")
+	c.SetAPIURL(srv.URL)
+
+	df, err := c.GetPeriodCashflow("2024Q2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(df.Rows) != queryPageSize+3 {
+		t.Errorf("got %d rows, want %d", len(df.Rows), queryPageSize+3)
+	}
+	if len(requests) != 2 {
+		t.Fatalf("got %d requests, want 2", len(requests))
+	}
+	for i, req := range requests {
+		if req.APIName != "cashflow_vip" || req.Params["period"] != "20240630" || req.Params["report_type"] != "1" {
+			t.Errorf("request %d: %s %v", i, req.APIName, req.Params)
+		}
+		if offset, _ := req.Params["offset"].(float64); int(offset) != i*queryPageSize {
+			t.Errorf("request %d: offset = %v, want %d", i, offset, i*queryPageSize)
+		}
+	}
+}
+
+func TestCashflowParams(t *testing.T) {
+	var requests []map[string]interface{}
+	c := newTestClient(t, &requests)
+
+	if _, err := c.GetYearCashflow("000001.SZ", "2023"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetCashflow(CashflowParams{TSCode: "000001", StartDate: "2024-01-01", EndDate: "2024/06/30"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	want := []map[string]string{
+		{"ts_code": "000001.SZ", "period": "20231231", "report_type": "1"},
+		{"ts_code": "000001.SZ", "start_date": "20240101", "end_date": "20240630"},
+	}
+	for i, w := range want {
+		params, _ := requests[i]["params"].(map[string]interface{})
+		for k, v := range w {
+			if params[k] != v {
+				t.Errorf("request %d: %s = %v, want %s", i, k, params[k], v)
+			}
+		}
+	}
+
+	if _, err := c.GetYearCashflow("000001.SZ", "FY23"); tsError.Cause(err) != tsError.ErrInvalidParameter {
+		t.Errorf("GetYearCashflow invalid year: err = %v, want ErrInvalidParameter", err)
+	}
+	if _, err := c.GetCashflow(CashflowParams{Period: "20240615"}, nil); tsError.Cause(err) != tsError.ErrInvalidParameter {
+		t.Errorf("GetCashflow invalid period: err = %v, want ErrInvalidParameter", err)
+	}
+}
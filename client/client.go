@@ -5,10 +5,14 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
+	"sync"
 	"time"
 
+	"github.com/Premium-Platform/go-tushare/pkg/cache"
+	"github.com/Premium-Platform/go-tushare/pkg/calendar"
 	tsError "github.com/Premium-Platform/go-tushare/pkg/errors"
 	"github.com/Premium-Platform/go-tushare/pkg/logger"
+	"github.com/Premium-Platform/go-tushare/pkg/symbol"
 	"github.com/Premium-Platform/go-tushare/pkg/types"
 )
 
@@ -18,15 +22,31 @@ const (
 
 	// DefaultAPIURL 默认API地址
 	DefaultAPIURL = "http://api.tushare.pro"
+
+	// DefaultConcurrency 批量获取数据时的默认并发数
+	DefaultConcurrency = 4
+
+	// queryPageSize 分页获取数据时每页的行数
+	queryPageSize = 5000
 )
 
 // Client TuShare API客户端
 type Client struct {
-	token   string
-	apiURL  string
-	timeout time.Duration
-	client  *http.Client
-	logger  *logger.Logger
+	token       string
+	apiURL      string
+	timeout     time.Duration
+	client      *http.Client
+	logger      *logger.Logger
+	limiter     *rateLimiter
+	concurrency int
+	limitMu     sync.Mutex
+	factors     *FactorStore
+	securities  *SecurityMaster
+	cache       *cache.Cache
+	memory      *cache.Memory
+	calendars   map[string]*calendar.Calendar
+	calendarMu  sync.Mutex
+	offlineCal  bool
 }
 
 // RequestParams 请求参数
@@ -50,11 +70,15 @@ type ResponseData struct {
 // New 创建一个新的客户端
 func New(token string) *Client {
 	client := &Client{
-		token:   token,
-		apiURL:  DefaultAPIURL,
-		timeout: DefaultTimeout,
-		client:  &http.Client{Timeout: DefaultTimeout},
-		logger:  logger.NewLogger(nil, logger.INFO),
+		token:       token,
+		apiURL:      DefaultAPIURL,
+		timeout:     DefaultTimeout,
+		client:      &http.Client{Timeout: DefaultTimeout},
+		logger:      logger.NewLogger(nil, logger.INFO),
+		concurrency: DefaultConcurrency,
+		factors:     NewFactorStore(),
+		securities:  NewSecurityMaster(),
+		calendars:   make(map[string]*calendar.Calendar),
 	}
 	return client
 }
@@ -88,16 +112,128 @@ func (c *Client) SetLogger(l *logger.Logger) {
 	c.logger = l
 }
 
+// QueryOption 单次查询的选项
+type QueryOption func(*queryOptions)
+
+// queryOptions 单次查询的选项
+type queryOptions struct {
+	bypassCache  bool
+	refreshCache bool
+}
+
+// WithBypassCache 本次查询不读取也不写入缓存
+func WithBypassCache() QueryOption {
+	return func(o *queryOptions) {
+		o.bypassCache = true
+	}
+}
+
+// WithRefreshCache 本次查询不读取缓存，重新请求后写入缓存
+func WithRefreshCache() QueryOption {
+	return func(o *queryOptions) {
+		o.refreshCache = true
+	}
+}
+
 // Query 通用API查询
-func (c *Client) Query(apiName string, params map[string]interface{}, fields []string) (*types.DataFrame, error) {
+//
+// 设置了进程内缓存（SetMemoryCache）时先读取进程内缓存，同时进行的相同查询只发出一次请求，
+// 结果按缓存策略的有效期写入进程内缓存（策略不缓存的接口不写入）；
+// 设置了查询缓存（SetCache）时再读取未过期的缓存，未命中时请求接口并写入缓存。
+// opts可跳过或刷新本次查询的缓存。从进程内缓存返回的DataFrame为副本，修改不会影响缓存。
+func (c *Client) Query(apiName string, params map[string]interface{}, fields []string, opts ...QueryOption) (*types.DataFrame, error) {
 	// 检查token
 	if c.token == "" {
 		c.logger.Error("无效的Token")
 		return nil, tsError.ErrInvalidToken
 	}
 
+	// 转换日期等参数为接口格式
+	params, err := wireParams(params)
+	if err != nil {
+		c.logger.Error("请求参数无效: %v", err)
+		return nil, err
+	}
+
+	var o queryOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	c.logger.Debug("开始查询API: %s, 参数: %v", apiName, params)
 
+	memory := c.memory
+	if memory == nil || o.bypassCache {
+		return c.query(apiName, params, fields, o)
+	}
+
+	key := cache.Key(apiName, params, fields)
+	if !o.refreshCache {
+		if v, ok := memory.Get(key); ok {
+			c.logger.Debug("命中进程内缓存: %s", apiName)
+			return v.(*types.DataFrame).Copy(), nil
+		}
+	}
+
+	v, shared, err := memory.Do(key, func() (interface{}, error) {
+		df, err := c.query(apiName, params, fields, o)
+		if err != nil {
+			return nil, err
+		}
+		memory.AddTTL(key, df, c.cachePolicy().TTL(apiName, params, time.Now()))
+		return df, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if shared {
+		c.logger.Debug("合并相同查询: %s", apiName)
+	}
+	return v.(*types.DataFrame).Copy(), nil
+}
+
+// query 读取查询缓存或请求接口
+func (c *Client) query(apiName string, params map[string]interface{}, fields []string, o queryOptions) (*types.DataFrame, error) {
+	// 读取缓存
+	if c.cache != nil {
+		switch {
+		case o.bypassCache:
+			c.cache.Bypass()
+		case o.refreshCache:
+			c.cache.Refresh()
+		default:
+			if data, ok := c.cache.Get(apiName, params, fields); ok {
+				var cached ResponseData
+				if err := json.Unmarshal(data, &cached.Data); err == nil {
+					c.logger.Debug("命中缓存: %s, 返回 %d 行数据", apiName, len(cached.Data.Items))
+					return responseFrame(&cached), nil
+				}
+				c.logger.Warn("缓存数据无效, 重新请求: %s", apiName)
+			}
+		}
+	}
+
+	respObj, err := c.request(apiName, params, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	// 写入缓存
+	if c.cache != nil && !o.bypassCache {
+		if data, err := json.Marshal(respObj.Data); err == nil {
+			if err := c.cache.Set(apiName, params, fields, data); err != nil {
+				c.logger.Warn("写入缓存失败: %v", err)
+			}
+		}
+	}
+
+	df := responseFrame(respObj)
+	c.logger.Debug("查询成功, 返回 %d 行数据", len(df.Rows))
+	return df, nil
+}
+
+// request 发送接口请求并检查响应状态
+func (c *Client) request(apiName string, params map[string]interface{}, fields []string) (*ResponseData, error) {
 	// 构建请求参数
 	reqParams := RequestParams{
 		APIName: apiName,
@@ -123,6 +259,11 @@ func (c *Client) Query(apiName string, params map[string]interface{}, fields []s
 	// 设置请求头
 	req.Header.Set("Content-Type", "application/json")
 
+	// 等待频率限制
+	if limiter := c.currentLimiter(); limiter != nil {
+		limiter.wait()
+	}
+
 	// 发送请求
 	c.logger.Debug("发送请求到 %s", c.apiURL)
 	resp, err := c.client.Do(req)
@@ -151,8 +292,11 @@ func (c *Client) Query(apiName string, params map[string]interface{}, fields []s
 		c.logger.Error("API返回错误: 代码=%d, 消息=%s", respObj.Code, respObj.Message)
 		return nil, tsError.NewAPIError(respObj.Code, respObj.Message)
 	}
+	return &respObj, nil
+}
 
-	// 将响应数据转换为DataFrame
+// responseFrame 将响应数据转换为DataFrame
+func responseFrame(respObj *ResponseData) *types.DataFrame {
 	columns := respObj.Data.Fields
 	rows := make([]map[string]interface{}, len(respObj.Data.Items))
 
@@ -165,7 +309,53 @@ func (c *Client) Query(apiName string, params map[string]interface{}, fields []s
 		}
 		rows[i] = row
 	}
+	return types.NewDataFrame(columns, rows)
+}
+
+// wireParams 将实现types.WireFormatter的参数值转换为接口格式，返回新的参数表
+//
+// ts_code参数同时规范化为TuShare格式（如 sz000001 转换为 000001.SZ），无法识别时返回ErrInvalidParameter
+func wireParams(params map[string]interface{}) (map[string]interface{}, error) {
+	result := make(map[string]interface{}, len(params))
+	for k, v := range params {
+		if w, ok := v.(types.WireFormatter); ok {
+			s, err := w.Wire()
+			if err != nil {
+				return nil, tsError.Wrapf(err, "parameter %s", k)
+			}
+			v = s
+		}
+		if s, ok := v.(string); ok && k == "ts_code" && s != "" {
+			normalized, err := symbol.NormalizeList(s)
+			if err != nil {
+				return nil, err
+			}
+			v = normalized
+		}
+		result[k] = v
+	}
+	return result, nil
+}
+
+// queryAll 按offset分页获取接口的全部数据
+func (c *Client) queryAll(apiName string, params map[string]interface{}, fields []string) (*types.DataFrame, error) {
+	frames := make([]*types.DataFrame, 0)
+	for offset := 0; ; offset += queryPageSize {
+		page := make(map[string]interface{}, len(params)+2)
+		for k, v := range params {
+			page[k] = v
+		}
+		page["limit"] = queryPageSize
+		page["offset"] = offset
 
-	c.logger.Debug("查询成功, 返回 %d 行数据", len(rows))
-	return types.NewDataFrame(columns, rows), nil
+		df, err := c.Query(apiName, page, fields)
+		if err != nil {
+			return nil, err
+		}
+		frames = append(frames, df)
+		if len(df.Rows) < queryPageSize {
+			break
+		}
+	}
+	return types.Concat(frames...), nil
 }
@@ -0,0 +1,85 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	tsError "github.com/Premium-Platform/go-tushare/pkg/errors"
+)
+
+// newTestClient 创建请求发往测试服务器的客户端，requests记录每次请求的接口名和参数
+func newTestClient(t *testing.T, requests *[]map[string]interface{}) *Client {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+		}
+		*requests = append(*requests, req)
+		w.Write([]byte(`{"code":0,"msg":"","data":{"fields":["ts_code"],"items":[["000001.SZ"]]}}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	c := New("token")
+	c.SetAPIURL(srv.URL)
+	return c
+}
+
+// TestDateParamsFromStringVariables 日期参数可以使用字符串变量赋值，发送前统一格式
+func TestDateParamsFromStringVariables(t *testing.T) {
+	var requests []map[string]interface{}
+	c := newTestClient(t, &requests)
+
+	period, annDate := "2024Q2", "2024-08-20"
+	if _, err := c.GetIncome(IncomeParams{TSCode: "000001", Period: period, AnnDate: annDate}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetYearBalanceSheet("000001.SZ", "2023"); err != nil {
+		t.Fatal(err)
+	}
+	start, end := "2024-01-02 09:30:00", "20240102"
+	if _, err := c.GetStockMinute(MinuteParams{TSCode: "000001.SZ", Freq: "1min", StartDate: start, EndDate: end}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []map[string]string{
+		{"ts_code": "000001.SZ", "period": "20240630", "ann_date": "20240820"},
+		{"period": "20231231", "report_type": "1"},
+		{"start_date": "2024-01-02 09:30:00", "end_date": "2024-01-02 00:00:00"},
+	}
+	if len(requests) != len(want) {
+		t.Fatalf("got %d requests, want %d", len(requests), len(want))
+	}
+	for i, w := range want {
+		params, _ := requests[i]["params"].(map[string]interface{})
+		for k, v := range w {
+			if params[k] != v {
+				t.Errorf("request %d: %s = %v, want %s", i, k, params[k], v)
+			}
+		}
+	}
+}
+
+// TestInvalidDateParams 格式错误的日期参数返回ErrInvalidParameter，不发出请求
+func TestInvalidDateParams(t *testing.T) {
+	var requests []map[string]interface{}
+	c := newTestClient(t, &requests)
+
+	calls := []func() error{
+		func() error { _, err := c.GetIncome(IncomeParams{Period: "20240615"}, nil); return err },
+		func() error { _, err := c.GetDailyBasic(DailyBasicParams{TradeDate: "2024-13-01"}, nil); return err },
+		func() error { _, err := c.Bar(BarParams{TsCode: "000001.SZ", StartDate: "yesterday"}); return err },
+		func() error { _, err := c.GetYearIncome("000001.SZ", "23"); return err },
+		func() error { _, err := c.GetYearBalanceSheet("000001.SZ", "2023年"); return err },
+	}
+	for i, call := range calls {
+		if err := call(); tsError.Cause(err) != tsError.ErrInvalidParameter {
+			t.Errorf("call %d: err = %v, want ErrInvalidParameter", i, err)
+		}
+	}
+	if len(requests) != 0 {
+		t.Errorf("got %d requests, want none", len(requests))
+	}
+}
@@ -0,0 +1,136 @@
+package client
+
+import (
+	"github.com/Premium-Platform/go-tushare/pkg/types"
+)
+
+// DailyBasicParams 每日指标查询参数
+type DailyBasicParams struct {
+	TSCode    string `json:"ts_code"`    // 股票代码
+	TradeDate string `json:"trade_date"` // 交易日期
+	StartDate string `json:"start_date"` // 开始日期
+	EndDate   string `json:"end_date"`   // 结束日期
+}
+
+// DailyBasicField 每日指标字段常量
+var DailyBasicField = struct {
+	TSCode        string
+	TradeDate     string
+	Close         string
+	TurnoverRate  string
+	TurnoverRateF string
+	VolumeRatio   string
+	PE            string
+	PETTM         string
+	PB            string
+	PS            string
+	PSTTM         string
+	DVRatio       string
+	DVTTM         string
+	TotalShare    string
+	FloatShare    string
+	FreeShare     string
+	TotalMV       string
+	CircMV        string
+}{
+	TSCode:        "ts_code",         // TS代码
+	TradeDate:     "trade_date",      // 交易日期
+	Close:         "close",           // 当日收盘价
+	TurnoverRate:  "turnover_rate",   // 换手率（%）
+	TurnoverRateF: "turnover_rate_f", // 换手率（自由流通股）
+	VolumeRatio:   "volume_ratio",    // 量比
+	PE:            "pe",              // 市盈率（总市值/净利润，亏损的PE为空）
+	PETTM:         "pe_ttm",          // 市盈率（TTM，亏损的PE为空）
+	PB:            "pb",              // 市净率（总市值/净资产）
+	PS:            "ps",              // 市销率
+	PSTTM:         "ps_ttm",          // 市销率（TTM）
+	DVRatio:       "dv_ratio",        // 股息率（%）
+	DVTTM:         "dv_ttm",          // 股息率（TTM）（%）
+	TotalShare:    "total_share",     // 总股本（万股）
+	FloatShare:    "float_share",     // 流通股本（万股）
+	FreeShare:     "free_share",      // 自由流通股本（万股）
+	TotalMV:       "total_mv",        // 总市值（万元）
+	CircMV:        "circ_mv",         // 流通市值（万元）
+}
+
+// GetDailyBasic 获取每日指标
+//
+// 接口参数：
+// - ts_code: 股票代码（ts_code与trade_date二选一）
+// - trade_date: 交易日期
+// - start_date: 开始日期
+// - end_date: 结束日期
+//
+// 返回字段：
+// - ts_code: TS代码
+// - trade_date: 交易日期
+// - close: 当日收盘价
+// - turnover_rate: 换手率（%）
+// - turnover_rate_f: 换手率（自由流通股）
+// - volume_ratio: 量比
+// - pe: 市盈率（总市值/净利润，亏损的PE为空）
+// - pe_ttm: 市盈率（TTM，亏损的PE为空）
+// - pb: 市净率（总市值/净资产）
+// - ps: 市销率
+// - ps_ttm: 市销率（TTM）
+// - dv_ratio: 股息率（%）
+// - dv_ttm: 股息率（TTM）（%）
+// - total_share: 总股本（万股）
+// - float_share: 流通股本（万股）
+// - free_share: 自由流通股本（万股）
+// - total_mv: 总市值（万元）
+// - circ_mv: 流通市值（万元）
+func (c *Client) GetDailyBasic(params DailyBasicParams, fields []string) (*types.DataFrame, error) {
+	// 构建请求参数
+	reqParams := map[string]interface{}{}
+
+	if params.TSCode != "" {
+		reqParams["ts_code"] = params.TSCode
+	}
+
+	if params.TradeDate != "" {
+		reqParams["trade_date"] = types.Date(params.TradeDate)
+	}
+
+	if params.StartDate != "" {
+		reqParams["start_date"] = types.Date(params.StartDate)
+	}
+
+	if params.EndDate != "" {
+		reqParams["end_date"] = types.Date(params.EndDate)
+	}
+
+	// 调用通用查询接口
+	return c.Query("daily_basic", reqParams, fields)
+}
+
+// GetStockDailyBasic 获取指定股票的每日指标（简化接口）
+func (c *Client) GetStockDailyBasic(tsCode string, startDate string, endDate string) (*types.DataFrame, error) {
+	return c.GetDailyBasic(DailyBasicParams{
+		TSCode:    tsCode,
+		StartDate: startDate,
+		EndDate:   endDate,
+	}, nil)
+}
+
+// GetDayDailyBasic 获取某一天全部股票的每日指标（简化接口）
+func (c *Client) GetDayDailyBasic(tradeDate string) (*types.DataFrame, error) {
+	return c.GetDailyBasic(DailyBasicParams{
+		TradeDate: tradeDate,
+	}, nil)
+}
+
+// CommonDailyBasicFields 返回常用的每日指标字段列表
+func (c *Client) CommonDailyBasicFields() []string {
+	return []string{
+		DailyBasicField.TSCode,
+		DailyBasicField.TradeDate,
+		DailyBasicField.TurnoverRate,
+		DailyBasicField.VolumeRatio,
+		DailyBasicField.PE,
+		DailyBasicField.PETTM,
+		DailyBasicField.PB,
+		DailyBasicField.TotalMV,
+		DailyBasicField.CircMV,
+	}
+}
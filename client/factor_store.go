@@ -0,0 +1,248 @@
+package client
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	tsError "github.com/Premium-Platform/go-tushare/pkg/errors"
+	"github.com/Premium-Platform/go-tushare/pkg/types"
+)
+
+// FactorStore 全市场最新复权因子
+//
+// 保存每只股票最新的复权因子，作为前复权的锚定因子。更新时只需获取最新交易日的全市场复权因子，
+// 当日没有复权因子的股票（如已退市）保留之前的因子；存储中没有的股票在使用时逐个查询后补充。
+// 可以使用Save/Load保存到本地，避免每次启动后重新查询已退市股票的复权因子。
+type FactorStore struct {
+	mu      sync.RWMutex
+	date    string             // 因子对应的交易日期
+	checked string             // 最近一次检查更新的日期
+	factors map[string]float64 // 股票代码 -> 最新复权因子
+}
+
+// NewFactorStore 创建复权因子存储
+func NewFactorStore() *FactorStore {
+	return &FactorStore{
+		factors: make(map[string]float64),
+	}
+}
+
+// Latest 获取股票最新的复权因子
+func (s *FactorStore) Latest(tsCode string) (float64, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	f, ok := s.factors[tsCode]
+	return f, ok
+}
+
+// Date 获取复权因子对应的交易日期，未更新过时为空
+func (s *FactorStore) Date() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.date
+}
+
+// Len 获取已保存复权因子的股票数量
+func (s *FactorStore) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.factors)
+}
+
+// Snapshot 获取全部复权因子的副本
+func (s *FactorStore) Snapshot() map[string]float64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	result := make(map[string]float64, len(s.factors))
+	for code, f := range s.factors {
+		result[code] = f
+	}
+	return result
+}
+
+// Update 使用某个交易日的全市场复权因子更新存储，早于当前日期的数据被忽略
+func (s *FactorStore) Update(tradeDate string, df *types.DataFrame) {
+	if df == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if tradeDate < s.date {
+		return
+	}
+	for _, row := range df.Rows {
+		code, _ := row[AdjFactorField.TSCode].(string)
+		if f, ok := types.ToFloat64(row[AdjFactorField.AdjFactor]); ok && code != "" && f > 0 {
+			s.factors[code] = f
+		}
+	}
+	s.date = tradeDate
+}
+
+// Add 补充存储中没有的股票的最新复权因子，不改变因子对应的交易日期；已有因子时忽略
+func (s *FactorStore) Add(tsCode string, factor float64) {
+	if tsCode == "" || factor <= 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.factors[tsCode]; !ok {
+		s.factors[tsCode] = factor
+	}
+}
+
+// factorStoreFile Save保存的复权因子存储
+type factorStoreFile struct {
+	Date    string             `json:"date"`
+	Factors map[string]float64 `json:"factors"`
+}
+
+// Save 将复权因子存储保存为JSON
+func (s *FactorStore) Save(w io.Writer) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if err := json.NewEncoder(w).Encode(factorStoreFile{Date: s.date, Factors: s.factors}); err != nil {
+		return tsError.Wrap(err, "failed to save factor store")
+	}
+	return nil
+}
+
+// Load 读取Save保存的复权因子存储，替换当前内容；读取后首次使用时仍会检查更新
+func (s *FactorStore) Load(r io.Reader) error {
+	var data factorStoreFile
+	if err := json.NewDecoder(r).Decode(&data); err != nil {
+		return tsError.Wrap(err, "failed to load factor store")
+	}
+
+	factors := make(map[string]float64, len(data.Factors))
+	for code, f := range data.Factors {
+		if code != "" && f > 0 {
+			factors[code] = f
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.date = data.Date
+	s.checked = ""
+	s.factors = factors
+	return nil
+}
+
+// FactorStore 获取客户端的复权因子存储
+func (c *Client) FactorStore() *FactorStore {
+	return c.factors
+}
+
+// RefreshFactorStore 获取最新交易日的全市场复权因子并更新存储
+func (c *Client) RefreshFactorStore() error {
+	today := time.Now().Format("20060102")
+	date, df, err := c.publishedFactors(today)
+	if err != nil {
+		return err
+	}
+	if df != nil {
+		c.factors.Update(date, df)
+	}
+
+	c.factors.mu.Lock()
+	c.factors.checked = today
+	c.factors.mu.Unlock()
+
+	c.logger.Debug("复权因子存储已更新, trade_date=%s, 股票数: %d", c.factors.Date(), c.factors.Len())
+	return nil
+}
+
+// latestFactors 获取全市场最新复权因子，当天未检查过更新时先更新存储
+func (c *Client) latestFactors() (map[string]float64, error) {
+	c.factors.mu.RLock()
+	checked := c.factors.checked
+	c.factors.mu.RUnlock()
+
+	if checked != time.Now().Format("20060102") {
+		if err := c.RefreshFactorStore(); err != nil {
+			return nil, err
+		}
+	}
+	return c.factors.Snapshot(), nil
+}
+
+// latestFactorsFor 获取指定股票的最新复权因子
+//
+// 优先使用复权因子存储；存储中没有的股票（如已退市）逐个查询该股票since之后的复权因子，
+// 取其最新的因子并补充到存储中。返回的map包含存储中的全部股票
+func (c *Client) latestFactorsFor(codes []string, since string) (map[string]float64, error) {
+	latest, err := c.latestFactors()
+	if err != nil {
+		return nil, err
+	}
+
+	missing := make([]string, 0)
+	for _, code := range codes {
+		if _, ok := latest[code]; !ok && !containsField(missing, code) {
+			missing = append(missing, code)
+		}
+	}
+	if len(missing) == 0 {
+		return latest, nil
+	}
+	c.logger.Debug("复权因子存储中没有%d只股票, 逐个获取最新复权因子", len(missing))
+
+	found := make([]float64, len(missing))
+	errs := make([]error, len(missing))
+	c.parallel(len(missing), func(i int) {
+		df, err := c.GetAdjFactor(AdjFactorParams{TSCode: missing[i], StartDate: since}, c.CommonAdjFactorFields())
+		if err != nil {
+			errs[i] = err
+			return
+		}
+		if factors := parseFactors(df); len(factors) > 0 {
+			found[i] = factors[len(factors)-1].factor
+		}
+	})
+
+	for i, code := range missing {
+		if errs[i] != nil {
+			c.logger.Error("获取复权因子失败, ts_code=%s: %v", code, errs[i])
+			return nil, errs[i]
+		}
+		if found[i] > 0 {
+			latest[code] = found[i]
+			c.factors.Add(code, found[i])
+		}
+	}
+	return latest, nil
+}
+
+// marketFactors 获取交易日的全市场复权因子，超过单次返回上限时分页获取
+func (c *Client) marketFactors(tradeDate string) (*types.DataFrame, error) {
+	return c.queryAll("adj_factor", map[string]interface{}{"trade_date": tradeDate}, c.CommonAdjFactorFields())
+}
+
+// publishedFactors 获取指定日期（含）之前最近一个已发布复权因子的交易日及其全市场复权因子
+//
+// 当日复权因子可能尚未发布，从最近的交易日向前查找；找不到时返回nil
+func (c *Client) publishedFactors(date string) (string, *types.DataFrame, error) {
+	end, err := time.Parse("20060102", date)
+	if err != nil {
+		return "", nil, tsError.Wrapf(tsError.ErrInvalidParameter, "invalid date %s", date)
+	}
+	days, err := c.tradingDays(end.AddDate(0, 0, -30).Format("20060102"), date)
+	if err != nil {
+		return "", nil, err
+	}
+
+	for i := len(days) - 1; i >= 0; i-- {
+		df, err := c.marketFactors(days[i])
+		if err != nil {
+			return "", nil, err
+		}
+		if len(df.Rows) > 0 {
+			return days[i], df, nil
+		}
+	}
+	return "", nil, nil
+}
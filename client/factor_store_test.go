@@ -0,0 +1,82 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestLatestFactorsForMissingCodes 存储中没有的股票逐个查询，以该股票最后一个复权因子为锚定因子
+func TestLatestFactorsForMissingCodes(t *testing.T) {
+	var queried []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Params map[string]string `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+		}
+		queried = append(queried, req.Params["ts_code"])
+		// 已退市股票，最后一个复权因子为1.5
+		w.Write([]byte(`{"code":0,"msg":"","data":{"fields":["ts_code","trade_date","adj_factor"],` +
+			`"items":[["600001.SH","20090922",1.5],["600001.SH","20090101",1.2]]}}`))
+	}))
+	defer srv.Close()
+
+	c := New("token")
+	c.SetAPIURL(srv.URL)
+	c.factors.factors["000001.SZ"] = 120.0
+	c.factors.checked = time.Now().Format("20060102")
+
+	latest, err := c.latestFactorsFor([]string{"000001.SZ", "600001.SH"}, "20090101")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latest["000001.SZ"] != 120.0 || latest["600001.SH"] != 1.5 {
+		t.Errorf("latest = %v, want 000001.SZ=120 600001.SH=1.5", latest)
+	}
+	if len(queried) != 1 || queried[0] != "600001.SH" {
+		t.Errorf("queried %v, want only 600001.SH", queried)
+	}
+
+	// 查询到的因子补充到存储中，再次使用时不再查询
+	if f, ok := c.FactorStore().Latest("600001.SH"); !ok || f != 1.5 {
+		t.Errorf("store 600001.SH = %v, %v, want 1.5", f, ok)
+	}
+	if _, err := c.latestFactorsFor([]string{"600001.SH"}, "20090101"); err != nil {
+		t.Fatal(err)
+	}
+	if len(queried) != 1 {
+		t.Errorf("got %d queries, want 1", len(queried))
+	}
+}
+
+func TestFactorStoreSaveLoad(t *testing.T) {
+	store := NewFactorStore()
+	store.date = "20240105"
+	store.Add("000001.SZ", 120.5)
+	store.Add("600001.SH", 1.5)
+
+	var buf bytes.Buffer
+	if err := store.Save(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := NewFactorStore()
+	if err := loaded.Load(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Date() != "20240105" || loaded.Len() != 2 {
+		t.Errorf("loaded date=%s len=%d, want 20240105 and 2", loaded.Date(), loaded.Len())
+	}
+	if f, _ := loaded.Latest("600001.SH"); f != 1.5 {
+		t.Errorf("loaded 600001.SH = %v, want 1.5", f)
+	}
+
+	if err := loaded.Load(bytes.NewBufferString("not json")); err == nil {
+		t.Error("Load invalid data: want error")
+	}
+}
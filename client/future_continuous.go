@@ -0,0 +1,349 @@
+package client
+
+import (
+	"sort"
+	"strings"
+
+	tsError "github.com/Premium-Platform/go-tushare/pkg/errors"
+	"github.com/Premium-Platform/go-tushare/pkg/types"
+)
+
+const (
+	// RollByMapping 使用fut_mapping的主力合约映射
+	RollByMapping = "mapping"
+	// RollByVolume 使用成交量最大的合约作为主力合约
+	RollByVolume = "volume"
+	// RollByOI 使用持仓量最大的合约作为主力合约
+	RollByOI = "oi"
+
+	// BackAdjustNone 不做换月调整
+	BackAdjustNone = "none"
+	// BackAdjustDiff 差价调整：换月前的价格加上新旧合约的价差
+	BackAdjustDiff = "diff"
+	// BackAdjustRatio 比例调整：换月前的价格乘以新旧合约的价格比
+	BackAdjustRatio = "ratio"
+)
+
+// futureExchangeSuffix 期货代码后缀与交易所的对应关系
+var futureExchangeSuffix = map[string]string{
+	"SHF": "SHFE",
+	"DCE": "DCE",
+	"ZCE": "CZCE",
+	"CFX": "CFFEX",
+	"INE": "INE",
+	"GFE": "GFEX",
+}
+
+// futurePriceFields 换月调整的价格字段
+var futurePriceFields = []string{"open", "high", "low", "close", "settle", "pre_close", "pre_settle"}
+
+// ContinuousParams 连续合约参数
+type ContinuousParams struct {
+	Symbol     string // 连续合约代码，如 RB.SHF（按成交量、持仓量换月时也可以只传品种代码 RB）
+	Exchange   string // 交易所 SHFE/DCE/CZCE/CFFEX/INE/GFEX，为空时由代码后缀推断
+	StartDate  string // 开始日期 YYYYMMDD
+	EndDate    string // 结束日期 YYYYMMDD
+	RollRule   string // 换月规则：mapping=fut_mapping主力映射（默认） volume=成交量最大 oi=持仓量最大
+	BackAdjust string // 换月调整：none=不调整（默认） diff=差价调整 ratio=比例调整
+}
+
+// ContinuousResult 连续合约结果
+type ContinuousResult struct {
+	Bars    *types.DataFrame // 连续合约行情，mapping_ts_code列为当日使用的具体合约
+	Mapping *types.DataFrame // 每日主力合约映射：trade_date, mapping_ts_code, is_roll, adjust
+}
+
+// FutureContinuous 构建期货连续合约
+//
+// 按换月规则确定每个交易日的主力合约，拼接各合约的日线行情。按成交量、持仓量换月时，
+// 使用前一交易日的成交量或持仓量决定当日主力合约（避免使用未来数据），且只向更远月份的合约换月。
+//
+// 换月调整为后向调整：最新的价格保持不变，换月日之前的价格按换月前一交易日新旧合约收盘价的
+// 价差（diff）或比例（ratio）调整。
+func (c *Client) FutureContinuous(params ContinuousParams) (*ContinuousResult, error) {
+	if params.Symbol == "" {
+		return nil, tsError.Wrap(tsError.ErrInvalidParameter, "symbol is required")
+	}
+	if err := normalizeDates(&params.StartDate, &params.EndDate); err != nil {
+		return nil, err
+	}
+	if params.RollRule == "" {
+		params.RollRule = RollByMapping
+	}
+	if params.BackAdjust == "" {
+		params.BackAdjust = BackAdjustNone
+	}
+
+	var mapping map[string]string
+	var contracts map[string]map[string]map[string]interface{}
+	var err error
+
+	switch params.RollRule {
+	case RollByMapping:
+		mapping, contracts, err = c.mappingMainContracts(params)
+	case RollByVolume, RollByOI:
+		mapping, contracts, err = c.rankedMainContracts(params)
+	default:
+		return nil, tsError.Wrapf(tsError.ErrInvalidParameter, "unknown roll rule %s", params.RollRule)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch params.BackAdjust {
+	case BackAdjustNone, BackAdjustDiff, BackAdjustRatio:
+	default:
+		return nil, tsError.Wrapf(tsError.ErrInvalidParameter, "unknown back adjust method %s", params.BackAdjust)
+	}
+
+	return spliceContinuous(params, mapping, contracts), nil
+}
+
+// mappingMainContracts 使用fut_mapping获取每日主力合约
+func (c *Client) mappingMainContracts(params ContinuousParams) (map[string]string, map[string]map[string]map[string]interface{}, error) {
+	df, err := c.Query("fut_mapping", map[string]interface{}{
+		"ts_code":    params.Symbol,
+		"start_date": params.StartDate,
+		"end_date":   params.EndDate,
+	}, []string{"ts_code", "trade_date", "mapping_ts_code"})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	mapping := make(map[string]string, len(df.Rows))
+	codes := make([]string, 0)
+	for _, row := range df.Rows {
+		date, _ := row["trade_date"].(string)
+		code, _ := row["mapping_ts_code"].(string)
+		if date == "" || code == "" {
+			continue
+		}
+		mapping[date] = code
+		if !containsField(codes, code) {
+			codes = append(codes, code)
+		}
+	}
+
+	contracts, err := c.fetchContracts(codes, params)
+	if err != nil {
+		return nil, nil, err
+	}
+	return mapping, contracts, nil
+}
+
+// rankedMainContracts 按成交量或持仓量确定每日主力合约
+func (c *Client) rankedMainContracts(params ContinuousParams) (map[string]string, map[string]map[string]map[string]interface{}, error) {
+	product := strings.ToUpper(params.Symbol)
+	suffix := ""
+	if idx := strings.Index(product, "."); idx >= 0 {
+		product, suffix = product[:idx], product[idx+1:]
+	}
+
+	exchange := params.Exchange
+	if exchange == "" {
+		exchange = futureExchangeSuffix[suffix]
+	}
+	if exchange == "" {
+		return nil, nil, tsError.Wrapf(tsError.ErrInvalidParameter, "cannot infer exchange of %s", params.Symbol)
+	}
+
+	// 获取品种在区间内交易过的普通合约
+	basic, err := c.Query("fut_basic", map[string]interface{}{
+		"exchange": exchange,
+		"fut_type": "1",
+	}, []string{"ts_code", "fut_code", "list_date", "delist_date"})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	delist := make(map[string]string)
+	codes := make([]string, 0)
+	for _, row := range basic.Rows {
+		code, _ := row["ts_code"].(string)
+		futCode, _ := row["fut_code"].(string)
+		listDate, _ := row["list_date"].(string)
+		delistDate, _ := row["delist_date"].(string)
+		if code == "" || strings.ToUpper(futCode) != product {
+			continue
+		}
+		if params.EndDate != "" && listDate != "" && listDate > params.EndDate {
+			continue
+		}
+		if params.StartDate != "" && delistDate != "" && delistDate < params.StartDate {
+			continue
+		}
+		delist[code] = delistDate
+		codes = append(codes, code)
+	}
+	if len(codes) == 0 {
+		return nil, nil, tsError.Wrapf(tsError.ErrInvalidParameter, "no contracts found for %s", params.Symbol)
+	}
+
+	contracts, err := c.fetchContracts(codes, params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 汇总交易日期
+	dates := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, bars := range contracts {
+		for date := range bars {
+			if !seen[date] {
+				seen[date] = true
+				dates = append(dates, date)
+			}
+		}
+	}
+	sort.Strings(dates)
+
+	field := "vol"
+	if params.RollRule == RollByOI {
+		field = "oi"
+	}
+
+	// 选出某个交易日指标最大的合约（只考虑不早于当前主力合约到期的合约）
+	best := func(date, current string) string {
+		bestCode, bestValue := "", -1.0
+		for _, code := range codes {
+			if current != "" && delist[code] < delist[current] {
+				continue
+			}
+			row, ok := contracts[code][date]
+			if !ok {
+				continue
+			}
+			if v, ok := types.ToFloat64(row[field]); ok && v > bestValue {
+				bestCode, bestValue = code, v
+			}
+		}
+		return bestCode
+	}
+
+	mapping := make(map[string]string, len(dates))
+	current := ""
+	for i, date := range dates {
+		signalDate := date
+		if i > 0 {
+			signalDate = dates[i-1]
+		}
+		if next := best(signalDate, current); next != "" {
+			current = next
+		}
+		// 主力合约已到期且当日无行情时，改用到期不早于它的合约中当日指标最大的合约；
+		// 未到期的主力合约当日无行情（如停牌）时保持不变，不向近月合约换月
+		if _, ok := contracts[current][date]; !ok && current != "" && delist[current] != "" && date >= delist[current] {
+			if next := best(date, current); next != "" {
+				current = next
+			}
+		}
+		if current != "" {
+			mapping[date] = current
+		}
+	}
+
+	return mapping, contracts, nil
+}
+
+// fetchContracts 获取各合约的日线行情，按合约代码和交易日期索引
+func (c *Client) fetchContracts(codes []string, params ContinuousParams) (map[string]map[string]map[string]interface{}, error) {
+	contracts := make(map[string]map[string]map[string]interface{}, len(codes))
+	for _, code := range codes {
+		df, err := c.Query("fut_daily", map[string]interface{}{
+			"ts_code":    code,
+			"start_date": params.StartDate,
+			"end_date":   params.EndDate,
+		}, []string{})
+		if err != nil {
+			c.logger.Error("获取合约行情失败, ts_code=%s: %v", code, err)
+			return nil, err
+		}
+
+		bars := make(map[string]map[string]interface{}, len(df.Rows))
+		for _, row := range df.Rows {
+			if date, ok := row["trade_date"].(string); ok {
+				bars[date] = row
+			}
+		}
+		contracts[code] = bars
+	}
+	return contracts, nil
+}
+
+// spliceContinuous 按每日主力合约拼接行情并做换月调整
+func spliceContinuous(params ContinuousParams, mapping map[string]string, contracts map[string]map[string]map[string]interface{}) *ContinuousResult {
+	dates := make([]string, 0, len(mapping))
+	for date, code := range mapping {
+		if _, ok := contracts[code][date]; ok {
+			dates = append(dates, date)
+		}
+	}
+	sort.Strings(dates)
+
+	columns := []string{"ts_code", "trade_date", "mapping_ts_code"}
+	rows := make([]map[string]interface{}, len(dates))
+	mapRows := make([]map[string]interface{}, len(dates))
+	for i, date := range dates {
+		code := mapping[date]
+		src := contracts[code][date]
+		row := make(map[string]interface{}, len(src)+1)
+		for k, v := range src {
+			row[k] = v
+			if i == 0 && !containsField(columns, k) {
+				columns = append(columns, k)
+			}
+		}
+		row["ts_code"] = params.Symbol
+		row["mapping_ts_code"] = code
+		rows[i] = row
+		mapRows[i] = map[string]interface{}{
+			"trade_date":      date,
+			"mapping_ts_code": code,
+			"is_roll":         i > 0 && mapping[dates[i-1]] != code,
+			"adjust":          nil,
+		}
+	}
+
+	// 从最新的换月日向前累积调整
+	if params.BackAdjust != BackAdjustNone {
+		offset, ratio := 0.0, 1.0
+		for i := len(dates) - 1; i > 0; i-- {
+			if mapRows[i]["is_roll"] == true {
+				oldCode, newCode := mapping[dates[i-1]], mapping[dates[i]]
+				// 使用换月前一交易日新旧合约的收盘价，新合约当日无行情时使用换月当日
+				oldClose, okOld := types.ToFloat64(contracts[oldCode][dates[i-1]]["close"])
+				newClose, okNew := types.ToFloat64(contracts[newCode][dates[i-1]]["close"])
+				if !okNew {
+					oldClose, okOld = types.ToFloat64(contracts[oldCode][dates[i]]["close"])
+					newClose, okNew = types.ToFloat64(contracts[newCode][dates[i]]["close"])
+				}
+				if okOld && okNew && oldClose != 0 {
+					if params.BackAdjust == BackAdjustDiff {
+						mapRows[i]["adjust"] = newClose - oldClose
+						offset += newClose - oldClose
+					} else {
+						mapRows[i]["adjust"] = newClose / oldClose
+						ratio *= newClose / oldClose
+					}
+				}
+			}
+
+			// 调整换月日之前的一行
+			row := rows[i-1]
+			for _, field := range futurePriceFields {
+				if v, ok := types.ToFloat64(row[field]); ok {
+					if params.BackAdjust == BackAdjustDiff {
+						row[field] = v + offset
+					} else {
+						row[field] = v * ratio
+					}
+				}
+			}
+		}
+	}
+
+	return &ContinuousResult{
+		Bars:    types.NewDataFrame(columns, rows),
+		Mapping: types.NewDataFrame([]string{"trade_date", "mapping_ts_code", "is_roll", "adjust"}, mapRows),
+	}
+}
@@ -0,0 +1,231 @@
+package client
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+
+	tsError "github.com/Premium-Platform/go-tushare/pkg/errors"
+)
+
+// continuousBars 测试合约的日线：收盘价、成交量、持仓量
+//
+// RB2405在20240104无行情（停牌），用于检查不会换回已被替换的近月合约
+var continuousBars = map[string]map[string][3]float64{
+	"RB2401.SHF": {
+		"20240102": {100, 500, 400},
+		"20240103": {101, 300, 300},
+		"20240104": {102, 800, 200},
+		"20240105": {103, 100, 100},
+	},
+	"RB2405.SHF": {
+		"20240102": {110, 200, 500},
+		"20240103": {112, 600, 600},
+		"20240105": {115, 700, 700},
+	},
+}
+
+// newContinuousClient 模拟fut_mapping、fut_basic和fut_daily接口，fut_daily返回bars中查询区间内的行情
+func newContinuousClient(t *testing.T, bars map[string]map[string][3]float64) *Client {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req RequestParams
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+		}
+
+		var fields []string
+		var items [][]interface{}
+		switch req.APIName {
+		case "fut_mapping":
+			fields = []string{"ts_code", "trade_date", "mapping_ts_code"}
+			items = [][]interface{}{
+				{"RB.SHF", "20240105", "RB2405.SHF"},
+				{"RB.SHF", "20240104", "RB2405.SHF"},
+				{"RB.SHF", "20240103", "RB2401.SHF"},
+				{"RB.SHF", "20240102", "RB2401.SHF"},
+			}
+		case "fut_basic":
+			fields = []string{"ts_code", "fut_code", "list_date", "delist_date"}
+			items = [][]interface{}{
+				{"RB2401.SHF", "RB", "20230116", "20240115"},
+				{"RB2405.SHF", "RB", "20230516", "20240515"},
+				{"HC2405.SHF", "HC", "20230516", "20240515"},
+				{"RB2310.SHF", "RB", "20221017", "20231016"},
+			}
+		case "fut_daily":
+			fields = []string{"ts_code", "trade_date", "close", "vol", "oi"}
+			code, _ := req.Params["ts_code"].(string)
+			for date, bar := range bars[code] {
+				if date < req.Params["start_date"].(string) || date > req.Params["end_date"].(string) {
+					continue
+				}
+				items = append(items, []interface{}{code, date, bar[0], bar[1], bar[2]})
+			}
+		default:
+			t.Errorf("unexpected api %s", req.APIName)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"code": 0,
+			"data": map[string]interface{}{"fields": fields, "items": items},
+		})
+	}))
+	t.Cleanup(srv.Close)
+
+	c := New("token")
+	c.SetAPIURL(srv.URL)
+	return c
+}
+
+// continuousRow 连续合约的一行：交易日期、合约和收盘价
+type continuousRow struct {
+	date, code string
+	close      float64
+}
+
+func continuousRows(t *testing.T, result *ContinuousResult) []continuousRow {
+	t.Helper()
+	rows := make([]continuousRow, 0, len(result.Bars.Rows))
+	for _, row := range result.Bars.Rows {
+		close, _ := row["close"].(float64)
+		rows = append(rows, continuousRow{row["trade_date"].(string), row["mapping_ts_code"].(string), close})
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].date < rows[j].date })
+	return rows
+}
+
+func checkContinuous(t *testing.T, name string, got, want []continuousRow) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Errorf("%s: rows = %v, want %v", name, got, want)
+		return
+	}
+	for i := range want {
+		if got[i].date != want[i].date || got[i].code != want[i].code || math.Abs(got[i].close-want[i].close) > 1e-9 {
+			t.Errorf("%s: rows = %v, want %v", name, got, want)
+			return
+		}
+	}
+}
+
+// TestFutureContinuousRollRules 按主力映射、成交量、持仓量确定每日主力合约
+func TestFutureContinuousRollRules(t *testing.T) {
+	c := newContinuousClient(t, continuousBars)
+	params := ContinuousParams{Symbol: "RB.SHF", StartDate: "20240102", EndDate: "20240105"}
+
+	// 主力映射：RB2405在20240104无行情，该日不输出
+	params.RollRule = RollByMapping
+	result, err := c.FutureContinuous(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkContinuous(t, "mapping", continuousRows(t, result), []continuousRow{
+		{"20240102", "RB2401.SHF", 100},
+		{"20240103", "RB2401.SHF", 101},
+		{"20240105", "RB2405.SHF", 115},
+	})
+	if rolls := result.Mapping.Rows; rolls[0]["is_roll"] != false || rolls[2]["is_roll"] != true {
+		t.Errorf("mapping is_roll = %v, want roll on 20240105", rolls)
+	}
+
+	// 成交量：20240103 RB2405成交量更大，20240104换月；20240104 RB2405停牌而RB2401成交量最大，
+	// RB2405尚未到期，不换回RB2401
+	params.RollRule = RollByVolume
+	if result, err = c.FutureContinuous(params); err != nil {
+		t.Fatal(err)
+	}
+	checkContinuous(t, "volume", continuousRows(t, result), []continuousRow{
+		{"20240102", "RB2401.SHF", 100},
+		{"20240103", "RB2401.SHF", 101},
+		{"20240105", "RB2405.SHF", 115},
+	})
+
+	// 持仓量：首日按当日持仓量选择RB2405，之后不再换月
+	params.RollRule = RollByOI
+	if result, err = c.FutureContinuous(params); err != nil {
+		t.Fatal(err)
+	}
+	checkContinuous(t, "oi", continuousRows(t, result), []continuousRow{
+		{"20240102", "RB2405.SHF", 110},
+		{"20240103", "RB2405.SHF", 112},
+		{"20240105", "RB2405.SHF", 115},
+	})
+
+	// 只传品种代码时需要指定交易所
+	params.Symbol = "RB"
+	if _, err := c.FutureContinuous(params); tsError.Cause(err) != tsError.ErrInvalidParameter {
+		t.Errorf("symbol without exchange: error = %v, want ErrInvalidParameter", err)
+	}
+	params.Exchange = "SHFE"
+	if _, err := c.FutureContinuous(params); err != nil {
+		t.Errorf("symbol with exchange: %v", err)
+	}
+
+	params.RollRule = "amount"
+	if _, err := c.FutureContinuous(params); tsError.Cause(err) != tsError.ErrInvalidParameter {
+		t.Errorf("unknown roll rule: error = %v, want ErrInvalidParameter", err)
+	}
+}
+
+// TestFutureContinuousRollAfterDelist 主力合约到期后换到到期更晚的合约中指标最大的合约
+func TestFutureContinuousRollAfterDelist(t *testing.T) {
+	// RB2401到期后的20240116没有行情，前一日RB2401成交量仍最大
+	c := newContinuousClient(t, map[string]map[string][3]float64{
+		"RB2401.SHF": {"20240115": {104, 900, 100}},
+		"RB2405.SHF": {"20240115": {116, 100, 800}, "20240116": {117, 100, 800}},
+	})
+
+	result, err := c.FutureContinuous(ContinuousParams{
+		Symbol:    "RB.SHF",
+		StartDate: "20240115",
+		EndDate:   "20240116",
+		RollRule:  RollByVolume,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkContinuous(t, "delist", continuousRows(t, result), []continuousRow{
+		{"20240115", "RB2401.SHF", 104},
+		{"20240116", "RB2405.SHF", 117},
+	})
+}
+
+// TestFutureContinuousBackAdjust 换月前的价格按换月前一交易日新旧合约收盘价的价差或比例调整
+func TestFutureContinuousBackAdjust(t *testing.T) {
+	c := newContinuousClient(t, continuousBars)
+	params := ContinuousParams{Symbol: "RB.SHF", StartDate: "20240102", EndDate: "20240105"}
+
+	// 换月前一交易日（20240103）RB2401收盘101，RB2405收盘112
+	params.BackAdjust = BackAdjustDiff
+	result, err := c.FutureContinuous(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkContinuous(t, "diff", continuousRows(t, result), []continuousRow{
+		{"20240102", "RB2401.SHF", 111},
+		{"20240103", "RB2401.SHF", 112},
+		{"20240105", "RB2405.SHF", 115},
+	})
+	if adjust := result.Mapping.Rows[2]["adjust"]; adjust != 11.0 {
+		t.Errorf("diff adjust = %v, want 11", adjust)
+	}
+
+	params.BackAdjust = BackAdjustRatio
+	if result, err = c.FutureContinuous(params); err != nil {
+		t.Fatal(err)
+	}
+	ratio := 112.0 / 101.0
+	checkContinuous(t, "ratio", continuousRows(t, result), []continuousRow{
+		{"20240102", "RB2401.SHF", 100 * ratio},
+		{"20240103", "RB2401.SHF", 101 * ratio},
+		{"20240105", "RB2405.SHF", 115},
+	})
+
+	params.BackAdjust = "log"
+	if _, err := c.FutureContinuous(params); tsError.Cause(err) != tsError.ErrInvalidParameter {
+		t.Errorf("unknown back adjust: error = %v, want ErrInvalidParameter", err)
+	}
+}
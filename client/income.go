@@ -155,19 +155,19 @@ func (c *Client) GetIncome(params IncomeParams, fields []string) (*types.DataFra
 	}
 
 	if params.AnnDate != "" {
-		reqParams["ann_date"] = params.AnnDate
+		reqParams["ann_date"] = types.Date(params.AnnDate)
 	}
 
 	if params.StartDate != "" {
-		reqParams["start_date"] = params.StartDate
+		reqParams["start_date"] = types.Date(params.StartDate)
 	}
 
 	if params.EndDate != "" {
-		reqParams["end_date"] = params.EndDate
+		reqParams["end_date"] = types.Date(params.EndDate)
 	}
 
 	if params.Period != "" {
-		reqParams["period"] = params.Period
+		reqParams["period"] = types.Period(params.Period)
 	}
 
 	if params.ReportType != "" {
@@ -192,10 +192,14 @@ func (c *Client) GetLatestIncome(tsCode string) (*types.DataFrame, error) {
 
 // GetYearIncome 获取年度利润表数据（简化接口）
 func (c *Client) GetYearIncome(tsCode string, year string) (*types.DataFrame, error) {
+	period, err := annualPeriod(year)
+	if err != nil {
+		return nil, err
+	}
 	return c.GetIncome(IncomeParams{
 		TSCode:     tsCode,
-		Period:     year + "1231", // 年度报表日期
-		ReportType: "1",           // 合并报表
+		Period:     period, // 年度报表日期
+		ReportType: "1",    // 合并报表
 	}, nil)
 }
 
@@ -0,0 +1,117 @@
+package client
+
+import (
+	"github.com/Premium-Platform/go-tushare/pkg/types"
+)
+
+// IndexBasicParams 指数基本信息查询参数
+type IndexBasicParams struct {
+	TSCode    string `json:"ts_code"`   // 指数代码
+	Name      string `json:"name"`      // 指数简称
+	Market    string `json:"market"`    // 交易所或服务商：MSCI CSI中证 SSE上交所 SZSE深交所 CICC中金 SW申万 OTH其他
+	Publisher string `json:"publisher"` // 发布商
+	Category  string `json:"category"`  // 指数类别
+}
+
+// IndexBasicField 指数基本信息字段常量
+var IndexBasicField = struct {
+	TSCode     string
+	Name       string
+	Fullname   string
+	Market     string
+	Publisher  string
+	IndexType  string
+	Category   string
+	BaseDate   string
+	BasePoint  string
+	ListDate   string
+	WeightRule string
+	Desc       string
+	ExpDate    string
+}{
+	TSCode:     "ts_code",     // TS代码
+	Name:       "name",        // 简称
+	Fullname:   "fullname",    // 指数全称
+	Market:     "market",      // 市场
+	Publisher:  "publisher",   // 发布方
+	IndexType:  "index_type",  // 指数风格
+	Category:   "category",    // 指数类别
+	BaseDate:   "base_date",   // 基期
+	BasePoint:  "base_point",  // 基点
+	ListDate:   "list_date",   // 发布日期
+	WeightRule: "weight_rule", // 加权方式
+	Desc:       "desc",        // 描述
+	ExpDate:    "exp_date",    // 终止日期
+}
+
+// GetIndexBasic 获取指数基本信息
+//
+// 接口参数：
+// - ts_code: 指数代码
+// - name: 指数简称
+// - market: 交易所或服务商，MSCI CSI中证 SSE上交所 SZSE深交所 CICC中金 SW申万 OTH其他
+// - publisher: 发布商
+// - category: 指数类别
+//
+// 返回字段：
+// - ts_code: TS代码
+// - name: 简称
+// - fullname: 指数全称
+// - market: 市场
+// - publisher: 发布方
+// - index_type: 指数风格
+// - category: 指数类别
+// - base_date: 基期
+// - base_point: 基点
+// - list_date: 发布日期
+// - weight_rule: 加权方式
+// - desc: 描述
+// - exp_date: 终止日期
+func (c *Client) GetIndexBasic(params IndexBasicParams, fields []string) (*types.DataFrame, error) {
+	// 构建请求参数
+	reqParams := map[string]interface{}{}
+
+	if params.TSCode != "" {
+		reqParams["ts_code"] = params.TSCode
+	}
+
+	if params.Name != "" {
+		reqParams["name"] = params.Name
+	}
+
+	if params.Market != "" {
+		reqParams["market"] = params.Market
+	}
+
+	if params.Publisher != "" {
+		reqParams["publisher"] = params.Publisher
+	}
+
+	if params.Category != "" {
+		reqParams["category"] = params.Category
+	}
+
+	// 调用通用查询接口
+	return c.Query("index_basic", reqParams, fields)
+}
+
+// GetMarketIndexes 获取指定市场的全部指数（简化接口）
+func (c *Client) GetMarketIndexes(market string) (*types.DataFrame, error) {
+	return c.GetIndexBasic(IndexBasicParams{
+		Market: market,
+	}, c.CommonIndexBasicFields())
+}
+
+// CommonIndexBasicFields 返回常用的指数基本信息字段列表
+func (c *Client) CommonIndexBasicFields() []string {
+	return []string{
+		IndexBasicField.TSCode,
+		IndexBasicField.Name,
+		IndexBasicField.Market,
+		IndexBasicField.Publisher,
+		IndexBasicField.Category,
+		IndexBasicField.BaseDate,
+		IndexBasicField.BasePoint,
+		IndexBasicField.ListDate,
+	}
+}
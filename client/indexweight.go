@@ -0,0 +1,84 @@
+package client
+
+import (
+	"github.com/Premium-Platform/go-tushare/pkg/types"
+)
+
+// IndexWeightParams 指数成分和权重查询参数
+type IndexWeightParams struct {
+	IndexCode string `json:"index_code"` // 指数代码
+	TradeDate string `json:"trade_date"` // 交易日期
+	StartDate string `json:"start_date"` // 开始日期
+	EndDate   string `json:"end_date"`   // 结束日期
+}
+
+// IndexWeightField 指数成分和权重字段常量
+var IndexWeightField = struct {
+	IndexCode string
+	ConCode   string
+	TradeDate string
+	Weight    string
+}{
+	IndexCode: "index_code", // 指数代码
+	ConCode:   "con_code",   // 成分股代码
+	TradeDate: "trade_date", // 交易日期
+	Weight:    "weight",     // 权重
+}
+
+// GetIndexWeight 获取指数成分和权重
+//
+// 数据按月度提供，每个交易日期为一期成分快照。
+//
+// 接口参数：
+// - index_code: 指数代码
+// - trade_date: 交易日期
+// - start_date: 开始日期
+// - end_date: 结束日期
+//
+// 返回字段：
+// - index_code: 指数代码
+// - con_code: 成分股代码
+// - trade_date: 交易日期
+// - weight: 权重
+func (c *Client) GetIndexWeight(params IndexWeightParams, fields []string) (*types.DataFrame, error) {
+	// 构建请求参数
+	reqParams := map[string]interface{}{}
+
+	if params.IndexCode != "" {
+		reqParams["index_code"] = params.IndexCode
+	}
+
+	if params.TradeDate != "" {
+		reqParams["trade_date"] = types.Date(params.TradeDate)
+	}
+
+	if params.StartDate != "" {
+		reqParams["start_date"] = types.Date(params.StartDate)
+	}
+
+	if params.EndDate != "" {
+		reqParams["end_date"] = types.Date(params.EndDate)
+	}
+
+	// 调用通用查询接口
+	return c.Query("index_weight", reqParams, fields)
+}
+
+// GetIndexWeightInPeriod 获取指定时间段内的指数成分和权重（简化接口）
+func (c *Client) GetIndexWeightInPeriod(indexCode string, startDate string, endDate string) (*types.DataFrame, error) {
+	return c.GetIndexWeight(IndexWeightParams{
+		IndexCode: indexCode,
+		StartDate: startDate,
+		EndDate:   endDate,
+	}, nil)
+}
+
+// CommonIndexWeightFields 返回常用的指数成分和权重字段列表
+func (c *Client) CommonIndexWeightFields() []string {
+	return []string{
+		IndexWeightField.IndexCode,
+		IndexWeightField.ConCode,
+		IndexWeightField.TradeDate,
+		IndexWeightField.Weight,
+	}
+}
@@ -0,0 +1,211 @@
+package client
+
+import (
+	"sort"
+
+	tsError "github.com/Premium-Platform/go-tushare/pkg/errors"
+	"github.com/Premium-Platform/go-tushare/pkg/types"
+)
+
+// indexWeightLookbackDays 获取指数成分时向前查找快照的天数，覆盖月度和季度调整的指数
+const indexWeightLookbackDays = 93
+
+// IndexMembership 指数历史成分
+//
+// index_weight按月度（部分指数按季度）提供成分快照，某一天的成分取该日（含）之前最近一期快照。
+type IndexMembership struct {
+	IndexCode string
+	dates     []string                    // 快照日期（升序）
+	snapshots map[string]*types.DataFrame // 快照日期 -> 成分和权重
+}
+
+// Dates 获取全部快照日期（升序）
+func (m *IndexMembership) Dates() []string {
+	return append([]string(nil), m.dates...)
+}
+
+// At 获取指定日期的成分和权重及对应的快照日期，早于第一期快照时返回nil
+//
+// 返回的DataFrame为副本，按成分股代码升序排列
+func (m *IndexMembership) At(date string) (*types.DataFrame, string) {
+	idx := sort.SearchStrings(m.dates, date)
+	if idx < len(m.dates) && m.dates[idx] == date {
+		idx++
+	}
+	if idx == 0 {
+		return nil, ""
+	}
+	snapshot := m.dates[idx-1]
+	return m.snapshots[snapshot].Copy(), snapshot
+}
+
+// Members 获取指定日期的成分股代码（升序）
+func (m *IndexMembership) Members(date string) []string {
+	df, _ := m.At(date)
+	if df == nil {
+		return []string{}
+	}
+	codes := make([]string, 0, len(df.Rows))
+	for _, row := range df.Rows {
+		code, _ := row[IndexWeightField.ConCode].(string)
+		codes = append(codes, code)
+	}
+	return codes
+}
+
+// newIndexMembership 按交易日期拆分index_weight数据
+func newIndexMembership(indexCode string, df *types.DataFrame) *IndexMembership {
+	m := &IndexMembership{
+		IndexCode: indexCode,
+		snapshots: make(map[string]*types.DataFrame),
+	}
+	for _, row := range df.Rows {
+		date, _ := row[IndexWeightField.TradeDate].(string)
+		if date == "" {
+			continue
+		}
+		snapshot, ok := m.snapshots[date]
+		if !ok {
+			snapshot = types.NewDataFrame(df.Columns, nil)
+			m.snapshots[date] = snapshot
+			m.dates = append(m.dates, date)
+		}
+		snapshot.Rows = append(snapshot.Rows, row)
+	}
+	sort.Strings(m.dates)
+	for _, snapshot := range m.snapshots {
+		sort.SliceStable(snapshot.Rows, func(i, j int) bool {
+			a, _ := snapshot.Rows[i][IndexWeightField.ConCode].(string)
+			b, _ := snapshot.Rows[j][IndexWeightField.ConCode].(string)
+			return a < b
+		})
+	}
+	return m
+}
+
+// IndexMembership 获取日期区间内的指数历史成分
+//
+// 开始日期向前多获取一个季度的快照，使区间内每一天都能取到之前最近一期成分
+func (c *Client) IndexMembership(indexCode, startDate, endDate string) (*IndexMembership, error) {
+	if indexCode == "" {
+		return nil, tsError.Wrap(tsError.ErrInvalidParameter, "index code is required")
+	}
+	start, err := types.ParseDate(startDate)
+	if err != nil {
+		return nil, err
+	}
+	end, err := types.ParseDate(endDate)
+	if err != nil {
+		return nil, err
+	}
+	if end < start {
+		return nil, tsError.Wrapf(tsError.ErrInvalidParameter, "start date %s is after end date %s", start, end)
+	}
+	startTime, _ := start.Time()
+	startDate, endDate = string(start), string(end)
+
+	c.logger.Debug("正在获取指数成分, index_code=%s, start_date=%s, end_date=%s", indexCode, startDate, endDate)
+
+	df, err := c.queryAll("index_weight", map[string]interface{}{
+		"index_code": indexCode,
+		"start_date": startTime.AddDate(0, 0, -indexWeightLookbackDays).Format("20060102"),
+		"end_date":   endDate,
+	}, c.CommonIndexWeightFields())
+	if err != nil {
+		c.logger.Error("获取指数成分失败: %v", err)
+		return nil, err
+	}
+	return newIndexMembership(indexCode, df), nil
+}
+
+// IndexConstituents 获取指数在指定日期的成分和权重
+//
+// 取该日（含）之前最近一期成分快照，返回的trade_date为快照日期；找不到快照时返回空的DataFrame
+func (c *Client) IndexConstituents(indexCode, date string) (*types.DataFrame, error) {
+	d, err := types.ParseDate(date)
+	if err != nil {
+		return nil, err
+	}
+	m, err := c.IndexMembership(indexCode, string(d), string(d))
+	if err != nil {
+		return nil, err
+	}
+	df, _ := m.At(string(d))
+	if df == nil {
+		return types.NewDataFrame(c.CommonIndexWeightFields(), nil), nil
+	}
+	return df, nil
+}
+
+// HSMembership 沪深股通历史成分
+//
+// 由hs_const的纳入日期和剔除日期构建，纳入日期当天起、剔除日期前一天止视为成分股
+type HSMembership struct {
+	HSType  string
+	periods map[string][][2]string // 股票代码 -> [纳入日期, 剔除日期)
+	codes   []string               // 股票代码（升序）
+}
+
+// Contains 判断股票在指定日期是否为成分股
+func (m *HSMembership) Contains(tsCode, date string) bool {
+	for _, p := range m.periods[tsCode] {
+		if date >= p[0] && (p[1] == "" || date < p[1]) {
+			return true
+		}
+	}
+	return false
+}
+
+// At 获取指定日期的成分股代码（升序）
+func (m *HSMembership) At(date string) []string {
+	result := make([]string, 0)
+	for _, code := range m.codes {
+		if m.Contains(code, date) {
+			result = append(result, code)
+		}
+	}
+	return result
+}
+
+// HSMembership 获取沪深股通历史成分
+//
+// hsType为SH沪股通、SZ深股通，为空时分别获取两者后合并；合并历史记录（is_new=0）和最新记录（is_new=1）
+func (c *Client) HSMembership(hsType string) (*HSMembership, error) {
+	if hsType != "" && hsType != "SH" && hsType != "SZ" {
+		return nil, tsError.Wrapf(tsError.ErrInvalidParameter, "unknown hs type %s", hsType)
+	}
+
+	m := &HSMembership{
+		HSType:  hsType,
+		periods: make(map[string][][2]string),
+	}
+	// hs_const必须指定hs_type
+	hsTypes := []string{hsType}
+	if hsType == "" {
+		hsTypes = []string{"SH", "SZ"}
+	}
+	for _, t := range hsTypes {
+		for _, isNew := range []string{"0", "1"} {
+			df, err := c.GetHSConst(HSConstParams{HSType: t, IsNew: isNew},
+				[]string{HSConstField.TsCode, HSConstField.InDate, HSConstField.OutDate})
+			if err != nil {
+				c.logger.Error("获取沪深股通成份股失败, hs_type=%s, is_new=%s: %v", t, isNew, err)
+				return nil, err
+			}
+			for _, row := range df.Rows {
+				code, _ := row[HSConstField.TsCode].(string)
+				in, _ := row[HSConstField.InDate].(string)
+				out, _ := row[HSConstField.OutDate].(string)
+				if code == "" || in == "" {
+					continue
+				}
+				if _, ok := m.periods[code]; !ok {
+					m.codes = append(m.codes, code)
+				}
+				m.periods[code] = append(m.periods[code], [2]string{in, out})
+			}
+		}
+	}
+	sort.Strings(m.codes)
+	return m, nil
+}
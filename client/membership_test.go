@@ -0,0 +1,145 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Premium-Platform/go-tushare/pkg/types"
+)
+
+func TestIndexMembershipAt(t *testing.T) {
+	columns := []string{"index_code", "con_code", "trade_date", "weight"}
+	rows := []map[string]interface{}{
+		{"index_code": "000300.SH", "con_code": "600000.SH", "trade_date": "20240131", "weight": 1.0},
+		{"index_code": "000300.SH", "con_code": "000001.SZ", "trade_date": "20240131", "weight": 2.0},
+		{"index_code": "000300.SH", "con_code": "600519.SH", "trade_date": "20240229", "weight": 3.0},
+	}
+	m := newIndexMembership("000300.SH", types.NewDataFrame(columns, rows))
+
+	tests := []struct {
+		date     string
+		snapshot string
+		members  []string
+	}{
+		{"20240130", "", []string{}},                                 // 早于第一期快照
+		{"20240131", "20240131", []string{"000001.SZ", "600000.SH"}}, // 快照当天
+		{"20240215", "20240131", []string{"000001.SZ", "600000.SH"}}, // 两期快照之间
+		{"20240229", "20240229", []string{"600519.SH"}},
+		{"20240601", "20240229", []string{"600519.SH"}},
+	}
+	for _, tt := range tests {
+		df, snapshot := m.At(tt.date)
+		if snapshot != tt.snapshot || (df == nil) != (tt.snapshot == "") {
+			t.Errorf("At(%s) snapshot = %q, want %q", tt.date, snapshot, tt.snapshot)
+		}
+		if got := m.Members(tt.date); !equalCodes(got, tt.members) {
+			t.Errorf("Members(%s) = %v, want %v", tt.date, got, tt.members)
+		}
+	}
+
+	// 返回副本，修改不影响快照
+	df, _ := m.At("20240131")
+	df.Rows[0]["weight"] = 99.0
+	if again, _ := m.At("20240131"); again.Rows[0]["weight"] == 99.0 {
+		t.Error("At returned a shared snapshot")
+	}
+}
+
+func TestIndexMembershipDateFormats(t *testing.T) {
+	var requests []map[string]interface{}
+	c := newTestClient(t, &requests)
+
+	if _, err := c.IndexMembership("000300.SH", "2024-01-02", "2024/03/29"); err != nil {
+		t.Fatal(err)
+	}
+	params, _ := requests[0]["params"].(map[string]interface{})
+	if params["start_date"] != "20231001" || params["end_date"] != "20240329" {
+		t.Errorf("params = %v, want start_date 20231001 and end_date 20240329", params)
+	}
+}
+
+func TestHSMembershipContains(t *testing.T) {
+	m := &HSMembership{
+		periods: map[string][][2]string{
+			"600000.SH": {{"20141117", "20180102"}, {"20190101", ""}},
+		},
+		codes: []string{"600000.SH"},
+	}
+	tests := []struct {
+		date string
+		want bool
+	}{
+		{"20141116", false},
+		{"20141117", true}, // 纳入日期当天起
+		{"20180101", true},
+		{"20180102", false}, // 剔除日期当天不含
+		{"20181231", false},
+		{"20190101", true},
+		{"20240101", true}, // 未剔除
+	}
+	for _, tt := range tests {
+		if got := m.Contains("600000.SH", tt.date); got != tt.want {
+			t.Errorf("Contains(%s) = %v, want %v", tt.date, got, tt.want)
+		}
+	}
+	if got := m.At("20180102"); len(got) != 0 {
+		t.Errorf("At(20180102) = %v, want none", got)
+	}
+}
+
+// TestHSMembershipBothTypes hs_type为空时分别获取沪股通和深股通
+func TestHSMembershipBothTypes(t *testing.T) {
+	seen := make(map[string]bool)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req RequestParams
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+		}
+		hsType, _ := req.Params["hs_type"].(string)
+		isNew, _ := req.Params["is_new"].(string)
+		seen[hsType+isNew] = true
+
+		items := [][]interface{}{}
+		if isNew == "1" {
+			code := "600000.SH"
+			if hsType == "SZ" {
+				code = "000001.SZ"
+			}
+			items = append(items, []interface{}{code, "20141117", nil})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"code": 0,
+			"data": map[string]interface{}{"fields": []string{"ts_code", "in_date", "out_date"}, "items": items},
+		})
+	}))
+	defer srv.Close()
+
+	c := New("token")
+	c.SetAPIURL(srv.URL)
+	m, err := c.HSMembership("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"SH0", "SH1", "SZ0", "SZ1"} {
+		if !seen[key] {
+			t.Errorf("missing request hs_type=%s is_new=%s", key[:2], key[2:])
+		}
+	}
+	if got := m.At("20240101"); !equalCodes(got, []string{"000001.SZ", "600000.SH"}) {
+		t.Errorf("At = %v", got)
+	}
+}
+
+func equalCodes(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -1,6 +1,9 @@
 package client
 
 import (
+	"strings"
+
+	"github.com/Premium-Platform/go-tushare/pkg/session"
 	"github.com/Premium-Platform/go-tushare/pkg/types"
 )
 
@@ -78,15 +81,15 @@ func (c *Client) GetStockMinute(params MinuteParams, fields []string) (*types.Da
 	}
 
 	if params.TradeDate != "" {
-		reqParams["trade_date"] = params.TradeDate
+		reqParams["trade_date"] = types.Date(params.TradeDate)
 	}
 
 	if params.StartDate != "" {
-		reqParams["start_date"] = params.StartDate
+		reqParams["start_date"] = types.DateTime(params.StartDate)
 	}
 
 	if params.EndDate != "" {
-		reqParams["end_date"] = params.EndDate
+		reqParams["end_date"] = types.DateTime(params.EndDate)
 	}
 
 	if params.StartTime != "" {
@@ -165,3 +168,42 @@ func (c *Client) CommonMinuteFields() []string {
 		MinuteField.Amount,
 	}
 }
+
+// MissingMinutes 检查分钟线数据在日期区间内缺失的K线时间
+//
+// 按证券代码确定交易时段（A股或期货品种，含夜盘），按交易日历列出区间内每个交易日应有的K线，
+// 返回数据中不存在的K线时间（YYYY-MM-DD HH:MM:SS，升序），整日缺失的交易日也会被列出。
+// freq为分钟数，如1、5、15。
+func (c *Client) MissingMinutes(df *types.DataFrame, tsCode string, freq int, startDate, endDate string) ([]string, error) {
+	sess, err := session.ForCode(tsCode)
+	if err != nil {
+		return nil, err
+	}
+
+	// 期货使用所在交易所的日历，股票使用上交所日历
+	exchange := "SSE"
+	if idx := strings.LastIndex(tsCode, "."); idx >= 0 {
+		if ex, ok := futureExchangeSuffix[strings.ToUpper(tsCode[idx+1:])]; ok {
+			exchange = ex
+		}
+	}
+	cal := c.Calendar(exchange)
+
+	dates, err := cal.TradingDaysBetween(datePart(startDate), datePart(endDate))
+	if err != nil {
+		return nil, err
+	}
+
+	days := make([]session.Day, 0, len(dates))
+	for _, date := range dates {
+		day := session.Day{Date: date}
+		if sess.HasNight() {
+			if day, err = session.TradingDay(cal, date); err != nil {
+				return nil, err
+			}
+		}
+		days = append(days, day)
+	}
+
+	return sess.MissingMinutes(df, freq, days...)
+}
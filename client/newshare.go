@@ -66,11 +66,11 @@ func (c *Client) GetNewShare(params NewShareParams, fields []string) (*types.Dat
 	reqParams := map[string]interface{}{}
 
 	if params.StartDate != "" {
-		reqParams["start_date"] = params.StartDate
+		reqParams["start_date"] = types.Date(params.StartDate)
 	}
 
 	if params.EndDate != "" {
-		reqParams["end_date"] = params.EndDate
+		reqParams["end_date"] = types.Date(params.EndDate)
 	}
 
 	// 调用通用查询接口
@@ -0,0 +1,80 @@
+package client
+
+import (
+	"sync"
+	"time"
+)
+
+// rateLimiter 按固定间隔放行请求的限速器
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// newRateLimiter 创建每分钟最多放行perMinute次请求的限速器
+func newRateLimiter(perMinute int) *rateLimiter {
+	return &rateLimiter{
+		interval: time.Minute / time.Duration(perMinute),
+	}
+}
+
+// wait 阻塞直到可以发送下一次请求
+func (r *rateLimiter) wait() {
+	r.mu.Lock()
+	now := time.Now()
+	if r.next.Before(now) {
+		r.next = now
+	}
+	delay := r.next.Sub(now)
+	r.next = r.next.Add(r.interval)
+	r.mu.Unlock()
+
+	if delay > 0 {
+		time.Sleep(delay)
+	}
+}
+
+// SetRateLimit 设置每分钟最大请求次数，0表示不限制
+//
+// TuShare按积分限制每分钟调用次数，并发获取数据时应设置为账户对应的上限
+func (c *Client) SetRateLimit(perMinute int) {
+	c.limitMu.Lock()
+	defer c.limitMu.Unlock()
+
+	if perMinute <= 0 {
+		c.limiter = nil
+		c.logger.Info("已取消请求频率限制")
+		return
+	}
+	c.limiter = newRateLimiter(perMinute)
+	c.logger.Info("请求频率限制已设置为每分钟 %d 次", perMinute)
+}
+
+// SetConcurrency 设置批量获取数据时的最大并发数
+func (c *Client) SetConcurrency(n int) {
+	if n <= 0 {
+		n = DefaultConcurrency
+	}
+	c.limitMu.Lock()
+	c.concurrency = n
+	c.limitMu.Unlock()
+	c.logger.Info("最大并发数已设置为 %d", n)
+}
+
+// currentLimiter 获取当前的限速器，未限制时为nil
+func (c *Client) currentLimiter() *rateLimiter {
+	c.limitMu.Lock()
+	defer c.limitMu.Unlock()
+	return c.limiter
+}
+
+// maxConcurrency 获取批量获取数据时的最大并发数
+func (c *Client) maxConcurrency() int {
+	c.limitMu.Lock()
+	defer c.limitMu.Unlock()
+	if c.concurrency <= 0 {
+		return DefaultConcurrency
+	}
+	return c.concurrency
+}
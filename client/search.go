@@ -0,0 +1,48 @@
+package client
+
+import (
+	"time"
+
+	"github.com/Premium-Platform/go-tushare/pkg/search"
+)
+
+// BuildSearchIndex 由证券主数据构建证券搜索索引，包含上市、退市和暂停上市的股票及其曾用名
+//
+// 支持按代码（000001、000001.SZ）、名称（平安）、拼音首字母（payh）和曾用名搜索；
+// 证券主数据为空或不是当天构建时先调用RefreshSecurityMaster
+func (c *Client) BuildSearchIndex() (*search.Index, error) {
+	if c.securities.Len() == 0 || c.securities.Date() < time.Now().Format("20060102") {
+		if err := c.RefreshSecurityMaster(); err != nil {
+			return nil, err
+		}
+	}
+
+	ix := search.NewIndex(c.securities.searchEntries())
+	c.logger.Debug("证券搜索索引已构建, 证券数: %d", ix.Len())
+	return ix, nil
+}
+
+// searchEntries 将证券主数据转换为搜索条目（按代码升序），曾用名去重
+func (m *SecurityMaster) searchEntries() []search.Entry {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	entries := make([]search.Entry, 0, len(m.codes))
+	for _, code := range m.codes {
+		s := m.securities[code]
+		e := search.Entry{
+			TSCode:     s.TSCode,
+			Symbol:     s.Symbol,
+			Name:       s.Name,
+			Cnspell:    s.Cnspell,
+			ListStatus: s.ListStatus,
+		}
+		for _, p := range s.Names {
+			if p.Name != "" && !containsField(e.Names, p.Name) {
+				e.Names = append(e.Names, p.Name)
+			}
+		}
+		entries = append(entries, e)
+	}
+	return entries
+}
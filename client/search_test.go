@@ -0,0 +1,59 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Premium-Platform/go-tushare/pkg/types"
+)
+
+// TestBuildSearchIndex 由当天构建的证券主数据直接生成索引，主数据为空时先重新构建
+func TestBuildSearchIndex(t *testing.T) {
+	var requests []map[string]interface{}
+	c := newTestClient(t, &requests)
+
+	basic := types.NewDataFrame(nil, []map[string]interface{}{
+		{"ts_code": "000001.SZ", "symbol": "000001", "name": "平安银行", "cnspell": "payh", "list_status": "L", "list_date": "19910403"},
+		{"ts_code": "000003.SZ", "symbol": "000003", "name": "PT金田A", "cnspell": "ptjta", "list_status": "D", "list_date": "19910703"},
+	})
+	names := types.NewDataFrame(nil, []map[string]interface{}{
+		{"ts_code": "000001.SZ", "name": "深发展A", "start_date": "19910403", "end_date": "20070619"},
+		{"ts_code": "000001.SZ", "name": "S深发展A", "start_date": "20070620", "end_date": "20071003"},
+		{"ts_code": "000001.SZ", "name": "深发展A", "start_date": "20071004", "end_date": "20120801"},
+		{"ts_code": "000001.SZ", "name": "平安银行", "start_date": "20120802"},
+	})
+	c.SecurityMaster().Update(time.Now().Format("20060102"), basic, names)
+
+	ix, err := c.BuildSearchIndex()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(requests) != 0 {
+		t.Errorf("got %d requests, want none", len(requests))
+	}
+	if ix.Len() != 2 {
+		t.Errorf("Len = %d, want 2", ix.Len())
+	}
+	results := ix.Search("深发展", 0)
+	if len(results) != 1 || results[0].TSCode != "000001.SZ" || !results[0].Historical {
+		t.Errorf("Search(深发展) = %+v, want historical hit of 000001.SZ", results)
+	}
+	if results := ix.Search("payh", 0); len(results) != 1 || results[0].Symbol != "000001" {
+		t.Errorf("Search(payh) = %+v, want 000001", results)
+	}
+
+	// 曾用名去重
+	entries := c.SecurityMaster().searchEntries()
+	if got := entries[0].Names; len(got) != 3 {
+		t.Errorf("names = %v, want 深发展A, S深发展A, 平安银行", got)
+	}
+
+	// 主数据不是当天构建时重新获取stock_basic和namechange
+	c.SecurityMaster().Update("20000101", basic, names)
+	if _, err := c.BuildSearchIndex(); err != nil {
+		t.Fatal(err)
+	}
+	if len(requests) != 4 {
+		t.Errorf("got %d requests, want 3 stock_basic and 1 namechange", len(requests))
+	}
+}
@@ -0,0 +1,340 @@
+package client
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	tsError "github.com/Premium-Platform/go-tushare/pkg/errors"
+	"github.com/Premium-Platform/go-tushare/pkg/types"
+)
+
+// NamePeriod 证券名称的使用区间
+type NamePeriod struct {
+	Name      string `json:"name"`       // 证券名称
+	StartDate string `json:"start_date"` // 开始日期
+	EndDate   string `json:"end_date"`   // 结束日期，为空表示至今
+}
+
+// Security 证券主数据
+type Security struct {
+	TSCode     string       `json:"ts_code"`     // TS代码
+	Symbol     string       `json:"symbol"`      // 股票代码
+	Name       string       `json:"name"`        // 当前名称
+	Cnspell    string       `json:"cnspell"`     // 当前名称的拼音首字母
+	Exchange   string       `json:"exchange"`    // 交易所 SSE上交所 SZSE深交所 BSE北交所
+	Market     string       `json:"market"`      // 市场类别（主板/创业板/科创板/CDR/北交所）
+	ListStatus string       `json:"list_status"` // 上市状态 L上市 D退市 P暂停上市
+	ListDate   string       `json:"list_date"`   // 上市日期
+	DelistDate string       `json:"delist_date"` // 退市日期
+	Names      []NamePeriod `json:"names"`       // 历史名称（按开始日期升序）
+}
+
+// ListedAt 指定日期是否处于上市状态（上市日期当天起，退市日期前一天止）
+func (s *Security) ListedAt(date string) bool {
+	if s.ListDate == "" || date < s.ListDate {
+		return false
+	}
+	return s.DelistDate == "" || date < s.DelistDate
+}
+
+// NameAt 获取指定日期的证券名称
+//
+// 取开始日期不晚于该日期的最后一个历史名称，早于全部历史名称时取最早的名称，没有历史名称时返回当前名称。
+// 不用当前名称填补历史名称的空缺，避免用到之后才发生的更名（如后来才被ST）
+func (s *Security) NameAt(date string) string {
+	if name, ok := s.historicalName(date); ok {
+		return name
+	}
+	return s.Name
+}
+
+// IsSTAt 指定日期是否为ST或*ST股票，没有历史名称时视为非ST
+func (s *Security) IsSTAt(date string) bool {
+	name, ok := s.historicalName(date)
+	return ok && isSTName(name)
+}
+
+// isSTName 判断证券名称是否带ST（含*ST）
+func isSTName(name string) bool {
+	return strings.Contains(strings.ToUpper(name), "ST")
+}
+
+// historicalName 从历史名称中获取指定日期的名称，没有历史名称时ok为false
+func (s *Security) historicalName(date string) (string, bool) {
+	if len(s.Names) == 0 {
+		return "", false
+	}
+	for i := len(s.Names) - 1; i >= 0; i-- {
+		if date >= s.Names[i].StartDate {
+			return s.Names[i].Name, true
+		}
+	}
+	return s.Names[0].Name, true
+}
+
+// UniverseFilter 历史股票池筛选条件
+type UniverseFilter struct {
+	Exchange    string // 交易所 SSE上交所 SZSE深交所 BSE北交所，为空不限
+	Market      string // 市场类别（主板/创业板/科创板/CDR/北交所），为空不限
+	ExcludeST   bool   // 是否排除当日为ST或*ST的股票
+	MinListDays int    // 截至当日至少已上市的交易日数（含上市首日），0不限
+}
+
+// SecurityMaster 证券主数据
+//
+// 由stock_basic全部上市状态（L/D/P）的股票和namechange曾用名构建，用于获取历史上某一天实际上市的股票，
+// 避免只使用当前上市股票带来的幸存者偏差。
+type SecurityMaster struct {
+	mu         sync.RWMutex
+	date       string               // 构建日期
+	securities map[string]*Security // 股票代码 -> 主数据
+	codes      []string             // 股票代码（升序）
+}
+
+// NewSecurityMaster 创建证券主数据
+func NewSecurityMaster() *SecurityMaster {
+	return &SecurityMaster{
+		securities: make(map[string]*Security),
+	}
+}
+
+// Date 获取构建日期，未构建时为空
+func (m *SecurityMaster) Date() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.date
+}
+
+// Len 获取证券数量
+func (m *SecurityMaster) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.codes)
+}
+
+// Get 获取证券主数据
+func (m *SecurityMaster) Get(tsCode string) (Security, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	s, ok := m.securities[tsCode]
+	if !ok {
+		return Security{}, false
+	}
+	return *s, true
+}
+
+// Update 使用stock_basic和namechange的数据重建主数据
+func (m *SecurityMaster) Update(date string, basic, names *types.DataFrame) {
+	securities := make(map[string]*Security)
+	if basic != nil {
+		for _, row := range basic.Rows {
+			code, _ := row[StockBasicField.TSCode].(string)
+			if code == "" {
+				continue
+			}
+			s := &Security{TSCode: code}
+			s.Symbol, _ = row[StockBasicField.Symbol].(string)
+			s.Name, _ = row[StockBasicField.Name].(string)
+			s.Cnspell, _ = row[StockBasicField.Cnspell].(string)
+			s.Exchange, _ = row[StockBasicField.Exchange].(string)
+			s.Market, _ = row[StockBasicField.Market].(string)
+			s.ListStatus, _ = row[StockBasicField.ListStatus].(string)
+			s.ListDate, _ = row[StockBasicField.ListDate].(string)
+			s.DelistDate, _ = row[StockBasicField.DelistDate].(string)
+			securities[code] = s
+		}
+	}
+	if names != nil {
+		for _, row := range names.Rows {
+			code, _ := row[NameChangeField.TsCode].(string)
+			s, ok := securities[code]
+			if !ok {
+				continue
+			}
+			s.Names = append(s.Names, namePeriod(row))
+		}
+	}
+	for _, s := range securities {
+		sortNames(s.Names)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.setLocked(date, securities)
+}
+
+// namePeriod 由namechange的一行构造名称区间
+func namePeriod(row map[string]interface{}) NamePeriod {
+	var p NamePeriod
+	p.Name, _ = row[NameChangeField.Name].(string)
+	p.StartDate, _ = row[NameChangeField.StartDate].(string)
+	p.EndDate, _ = row[NameChangeField.EndDate].(string)
+	return p
+}
+
+// sortNames 按开始日期升序排列历史名称
+func sortNames(names []NamePeriod) {
+	sort.SliceStable(names, func(i, j int) bool { return names[i].StartDate < names[j].StartDate })
+}
+
+// setLocked 替换主数据，调用方需持有写锁
+func (m *SecurityMaster) setLocked(date string, securities map[string]*Security) {
+	codes := make([]string, 0, len(securities))
+	for code := range securities {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	m.date = date
+	m.securities = securities
+	m.codes = codes
+}
+
+// UniverseAt 获取指定日期处于上市状态且满足筛选条件的股票代码（升序）
+//
+// MinListDays按上市日期不晚于listedBy判断，listedBy为空时忽略该条件；
+// 需要按交易日计算时使用Client.UniverseAt
+func (m *SecurityMaster) UniverseAt(date string, filter UniverseFilter, listedBy string) []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := make([]string, 0)
+	for _, code := range m.codes {
+		s := m.securities[code]
+		if !s.ListedAt(date) {
+			continue
+		}
+		if filter.Exchange != "" && s.Exchange != filter.Exchange {
+			continue
+		}
+		if filter.Market != "" && s.Market != filter.Market {
+			continue
+		}
+		if filter.ExcludeST && s.IsSTAt(date) {
+			continue
+		}
+		if listedBy != "" && s.ListDate > listedBy {
+			continue
+		}
+		result = append(result, code)
+	}
+	return result
+}
+
+// Save 以JSON格式保存主数据，可用Load在本地恢复
+func (m *SecurityMaster) Save(w io.Writer) error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	data := struct {
+		Date       string      `json:"date"`
+		Securities []*Security `json:"securities"`
+	}{Date: m.date, Securities: make([]*Security, 0, len(m.codes))}
+	for _, code := range m.codes {
+		data.Securities = append(data.Securities, m.securities[code])
+	}
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		return tsError.Wrap(err, "failed to save security master")
+	}
+	return nil
+}
+
+// Load 读取Save保存的主数据
+func (m *SecurityMaster) Load(r io.Reader) error {
+	var data struct {
+		Date       string      `json:"date"`
+		Securities []*Security `json:"securities"`
+	}
+	if err := json.NewDecoder(r).Decode(&data); err != nil {
+		return tsError.Wrap(err, "failed to load security master")
+	}
+
+	securities := make(map[string]*Security, len(data.Securities))
+	for _, s := range data.Securities {
+		if s != nil && s.TSCode != "" {
+			securities[s.TSCode] = s
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.setLocked(data.Date, securities)
+	return nil
+}
+
+// SecurityMaster 获取客户端的证券主数据
+func (c *Client) SecurityMaster() *SecurityMaster {
+	return c.securities
+}
+
+// RefreshSecurityMaster 获取全部上市状态的股票列表和曾用名，重建证券主数据
+func (c *Client) RefreshSecurityMaster() error {
+	fields := []string{
+		StockBasicField.TSCode, StockBasicField.Symbol, StockBasicField.Name, StockBasicField.Cnspell,
+		StockBasicField.Exchange, StockBasicField.Market,
+		StockBasicField.ListStatus, StockBasicField.ListDate, StockBasicField.DelistDate,
+	}
+	frames := make([]*types.DataFrame, 0, 3)
+	for _, status := range []string{"L", "D", "P"} {
+		df, err := c.GetStockBasic(StockBasicParams{ListStatus: status}, fields)
+		if err != nil {
+			c.logger.Error("获取股票列表失败, list_status=%s: %v", status, err)
+			return err
+		}
+		frames = append(frames, df)
+	}
+
+	names, err := c.queryAll("namechange", map[string]interface{}{},
+		[]string{NameChangeField.TsCode, NameChangeField.Name, NameChangeField.StartDate, NameChangeField.EndDate})
+	if err != nil {
+		c.logger.Error("获取曾用名失败: %v", err)
+		return err
+	}
+
+	c.securities.Update(time.Now().Format("20060102"), types.Concat(frames...), names)
+	c.logger.Debug("证券主数据已更新, 股票数: %d", c.securities.Len())
+	return nil
+}
+
+// UniverseAt 获取指定日期实际上市且满足筛选条件的股票代码（升序）
+//
+// 证券主数据为空或构建日期早于指定日期时先重新构建，因此用Load恢复的本地主数据可直接回答构建日期之前的查询；
+// MinListDays按上交所交易日历计算，指定日期不是交易日时从之前最近的交易日起算。
+func (c *Client) UniverseAt(date string, filter UniverseFilter) ([]string, error) {
+	d, err := types.ParseDate(date)
+	if err != nil {
+		return nil, err
+	}
+	date = string(d)
+	if filter.MinListDays < 0 {
+		return nil, tsError.Wrapf(tsError.ErrInvalidParameter, "invalid min list days %d", filter.MinListDays)
+	}
+
+	if c.securities.Len() == 0 || c.securities.Date() < date {
+		if err = c.RefreshSecurityMaster(); err != nil {
+			return nil, err
+		}
+	}
+
+	listedBy := ""
+	if filter.MinListDays > 0 {
+		cal := c.Calendar("SSE")
+		anchor := date
+		open, err := cal.IsTradingDay(date)
+		if err != nil {
+			return nil, err
+		}
+		if !open {
+			if anchor, err = cal.PrevTradingDay(date); err != nil {
+				return nil, err
+			}
+		}
+		if listedBy, err = cal.Offset(anchor, 1-filter.MinListDays); err != nil {
+			return nil, err
+		}
+	}
+	return c.securities.UniverseAt(date, filter, listedBy), nil
+}
@@ -0,0 +1,200 @@
+package client
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	tsError "github.com/Premium-Platform/go-tushare/pkg/errors"
+	"github.com/Premium-Platform/go-tushare/pkg/types"
+)
+
+// TestNameAt 历史名称没有覆盖的日期不使用当前名称，避免提前标记为ST
+func TestNameAt(t *testing.T) {
+	s := &Security{
+		TSCode: "600001.SH",
+		Name:   "*ST样例",
+		Names: []NamePeriod{
+			{Name: "样例股份", StartDate: "20050101", EndDate: "20091231"},
+			{Name: "ST样例", StartDate: "20120501", EndDate: "20151231"},
+			{Name: "*ST样例", StartDate: "20160101"},
+		},
+	}
+
+	tests := []struct {
+		date string
+		name string
+		st   bool
+	}{
+		{"20000101", "样例股份", false}, // 早于全部历史名称，取最早的名称
+		{"20080101", "样例股份", false},
+		{"20110101", "样例股份", false}, // 历史名称之间的空缺，沿用之前的名称
+		{"20130101", "ST样例", true},
+		{"20200101", "*ST样例", true},
+	}
+	for _, tt := range tests {
+		if got := s.NameAt(tt.date); got != tt.name {
+			t.Errorf("NameAt(%s) = %s, want %s", tt.date, got, tt.name)
+		}
+		if got := s.IsSTAt(tt.date); got != tt.st {
+			t.Errorf("IsSTAt(%s) = %v, want %v", tt.date, got, tt.st)
+		}
+	}
+
+	// 没有历史名称时返回当前名称，但不视为ST
+	s.Names = nil
+	if got := s.NameAt("20000101"); got != "*ST样例" {
+		t.Errorf("NameAt without history = %s, want current name", got)
+	}
+	if s.IsSTAt("20000101") {
+		t.Error("IsSTAt without history = true, want false")
+	}
+}
+
+// TestListedAt 上市日期当天起、退市日期前一天止视为上市
+func TestListedAt(t *testing.T) {
+	s := &Security{ListDate: "20100105", DelistDate: "20200601"}
+	tests := []struct {
+		date string
+		want bool
+	}{
+		{"20100104", false},
+		{"20100105", true},
+		{"20200531", true},
+		{"20200601", false},
+	}
+	for _, tt := range tests {
+		if got := s.ListedAt(tt.date); got != tt.want {
+			t.Errorf("ListedAt(%s) = %v, want %v", tt.date, got, tt.want)
+		}
+	}
+
+	if (&Security{ListDate: "20100105"}).ListedAt("20300101") != true {
+		t.Error("ListedAt without delist date = false, want true")
+	}
+	if (&Security{}).ListedAt("20100105") {
+		t.Error("ListedAt without list date = true, want false")
+	}
+}
+
+// testMaster 测试用证券主数据，构建日期为当天
+func testMaster() *SecurityMaster {
+	basic := types.NewDataFrame(nil, []map[string]interface{}{
+		{"ts_code": "600000.SH", "name": "浦发银行", "exchange": "SSE", "market": "主板", "list_status": "L", "list_date": "19991110"},
+		{"ts_code": "000001.SZ", "name": "平安银行", "exchange": "SZSE", "market": "主板", "list_status": "L", "list_date": "19910403"},
+		{"ts_code": "300001.SZ", "name": "特锐德", "exchange": "SZSE", "market": "创业板", "list_status": "L", "list_date": "20091030"},
+		{"ts_code": "000003.SZ", "name": "PT金田A", "exchange": "SZSE", "market": "主板", "list_status": "D", "list_date": "19910703", "delist_date": "20020614"},
+		{"ts_code": "600001.SH", "name": "邯郸钢铁", "exchange": "SSE", "market": "主板", "list_status": "D", "list_date": "19980122", "delist_date": "20091229"},
+		{"ts_code": "688001.SH", "name": "华兴源创", "exchange": "SSE", "market": "科创板", "list_status": "L", "list_date": "20240109"},
+		{"ts_code": "688002.SH", "name": "睿创微纳", "exchange": "SSE", "market": "科创板", "list_status": "L", "list_date": "20240108"},
+	})
+	names := types.NewDataFrame(nil, []map[string]interface{}{
+		{"ts_code": "000003.SZ", "name": "金田实业", "start_date": "19910703", "end_date": "19990701"},
+		{"ts_code": "000003.SZ", "name": "ST金田", "start_date": "19990702", "end_date": "20010101"},
+		{"ts_code": "000003.SZ", "name": "PT金田A", "start_date": "20010102"},
+		{"ts_code": "300001.SZ", "name": "特锐德", "start_date": "20091030"},
+	})
+	m := NewSecurityMaster()
+	m.Update(time.Now().Format("20060102"), basic, names)
+	return m
+}
+
+func TestSecurityMasterUniverseAt(t *testing.T) {
+	m := testMaster()
+
+	tests := []struct {
+		date     string
+		filter   UniverseFilter
+		listedBy string
+		want     string
+	}{
+		// 000003.SZ于20020614退市，当天不再上市
+		{"20020613", UniverseFilter{}, "", "000001.SZ 000003.SZ 600000.SH 600001.SH"},
+		{"20020614", UniverseFilter{}, "", "000001.SZ 600000.SH 600001.SH"},
+		{"20020613", UniverseFilter{Exchange: "SZSE"}, "", "000001.SZ 000003.SZ"},
+		{"20000101", UniverseFilter{ExcludeST: true}, "", "000001.SZ 600000.SH 600001.SH"}, // 当日名称为ST金田
+		{"19990601", UniverseFilter{ExcludeST: true}, "", "000001.SZ 000003.SZ 600001.SH"},
+		{"20020613", UniverseFilter{ExcludeST: true}, "", "000001.SZ 000003.SZ 600000.SH 600001.SH"},
+		{"20240110", UniverseFilter{Market: "科创板"}, "", "688001.SH 688002.SH"},
+		{"20240110", UniverseFilter{Exchange: "SSE", Market: "科创板"}, "20240108", "688002.SH"},
+		{"20240108", UniverseFilter{Market: "创业板"}, "", "300001.SZ"},
+		{"20240108", UniverseFilter{Exchange: "BSE"}, "", ""},
+	}
+	for _, tt := range tests {
+		got := strings.Join(m.UniverseAt(tt.date, tt.filter, tt.listedBy), " ")
+		if got != tt.want {
+			t.Errorf("UniverseAt(%s, %+v, %q) = %s, want %s", tt.date, tt.filter, tt.listedBy, got, tt.want)
+		}
+	}
+}
+
+// TestClientUniverseAt MinListDays按上交所交易日历计算，构建日期不早于查询日期时不重新获取主数据
+func TestClientUniverseAt(t *testing.T) {
+	var requests []map[string]interface{}
+	c := newTestClient(t, &requests)
+	c.SetOfflineCalendar(true)
+	c.securities = testMaster()
+
+	tests := []struct {
+		date    string
+		minDays int
+		want    string
+	}{
+		// 20240110往前3个交易日（含当日）为20240108
+		{"20240110", 3, "688002.SH"},
+		{"20240110", 2, "688001.SH 688002.SH"},
+		// 20240113为周六，从20240112起算
+		{"20240113", 4, "688001.SH 688002.SH"},
+		{"20240113", 5, "688002.SH"},
+		{"2024-01-10", 0, "688001.SH 688002.SH"},
+	}
+	for _, tt := range tests {
+		codes, err := c.UniverseAt(tt.date, UniverseFilter{Market: "科创板", MinListDays: tt.minDays})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := strings.Join(codes, " "); got != tt.want {
+			t.Errorf("UniverseAt(%s, MinListDays=%d) = %s, want %s", tt.date, tt.minDays, got, tt.want)
+		}
+	}
+	if len(requests) != 0 {
+		t.Errorf("got %d requests, want none", len(requests))
+	}
+
+	if _, err := c.UniverseAt("20240110", UniverseFilter{MinListDays: -1}); tsError.Cause(err) != tsError.ErrInvalidParameter {
+		t.Errorf("negative MinListDays: error = %v, want ErrInvalidParameter", err)
+	}
+	if _, err := c.UniverseAt("2024011", UniverseFilter{}); tsError.Cause(err) != tsError.ErrInvalidParameter {
+		t.Errorf("invalid date: error = %v, want ErrInvalidParameter", err)
+	}
+}
+
+func TestSecurityMasterSaveLoad(t *testing.T) {
+	m := testMaster()
+
+	var buf bytes.Buffer
+	if err := m.Save(&buf); err != nil {
+		t.Fatal(err)
+	}
+	loaded := NewSecurityMaster()
+	if err := loaded.Load(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	if loaded.Date() != m.Date() || loaded.Len() != m.Len() {
+		t.Errorf("loaded date=%s len=%d, want %s and %d", loaded.Date(), loaded.Len(), m.Date(), m.Len())
+	}
+	s, ok := loaded.Get("000003.SZ")
+	if !ok || s.NameAt("19950101") != "金田实业" || s.DelistDate != "20020614" || s.Market != "主板" {
+		t.Errorf("loaded 000003.SZ = %+v", s)
+	}
+	filter := UniverseFilter{ExcludeST: true}
+	if got, want := loaded.UniverseAt("20000101", filter, ""), m.UniverseAt("20000101", filter, ""); strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("loaded UniverseAt = %v, want %v", got, want)
+	}
+
+	if err := loaded.Load(bytes.NewBufferString("not json")); err == nil {
+		t.Error("Load invalid data: want error")
+	}
+}
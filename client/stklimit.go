@@ -0,0 +1,92 @@
+package client
+
+import (
+	"github.com/Premium-Platform/go-tushare/pkg/types"
+)
+
+// StkLimitParams 每日涨跌停价格查询参数
+type StkLimitParams struct {
+	TSCode    string `json:"ts_code"`    // 股票代码
+	TradeDate string `json:"trade_date"` // 交易日期
+	StartDate string `json:"start_date"` // 开始日期
+	EndDate   string `json:"end_date"`   // 结束日期
+}
+
+// StkLimitField 每日涨跌停价格字段常量
+var StkLimitField = struct {
+	TSCode    string
+	TradeDate string
+	PreClose  string
+	UpLimit   string
+	DownLimit string
+}{
+	TSCode:    "ts_code",    // TS代码
+	TradeDate: "trade_date", // 交易日期
+	PreClose:  "pre_close",  // 昨日收盘价
+	UpLimit:   "up_limit",   // 涨停价
+	DownLimit: "down_limit", // 跌停价
+}
+
+// GetStkLimit 获取每日涨跌停价格
+//
+// 接口参数：
+// - ts_code: 股票代码
+// - trade_date: 交易日期
+// - start_date: 开始日期
+// - end_date: 结束日期
+//
+// 返回字段：
+// - trade_date: 交易日期
+// - ts_code: TS代码
+// - pre_close: 昨日收盘价
+// - up_limit: 涨停价
+// - down_limit: 跌停价
+func (c *Client) GetStkLimit(params StkLimitParams, fields []string) (*types.DataFrame, error) {
+	// 构建请求参数
+	reqParams := map[string]interface{}{}
+
+	if params.TSCode != "" {
+		reqParams["ts_code"] = params.TSCode
+	}
+
+	if params.TradeDate != "" {
+		reqParams["trade_date"] = types.Date(params.TradeDate)
+	}
+
+	if params.StartDate != "" {
+		reqParams["start_date"] = types.Date(params.StartDate)
+	}
+
+	if params.EndDate != "" {
+		reqParams["end_date"] = types.Date(params.EndDate)
+	}
+
+	// 调用通用查询接口
+	return c.Query("stk_limit", reqParams, fields)
+}
+
+// GetStockStkLimit 获取指定股票的涨跌停价格（简化接口）
+func (c *Client) GetStockStkLimit(tsCode string, startDate string, endDate string) (*types.DataFrame, error) {
+	return c.GetStkLimit(StkLimitParams{
+		TSCode:    tsCode,
+		StartDate: startDate,
+		EndDate:   endDate,
+	}, nil)
+}
+
+// GetDayStkLimit 获取某一天全部股票的涨跌停价格（简化接口）
+func (c *Client) GetDayStkLimit(tradeDate string) (*types.DataFrame, error) {
+	return c.GetStkLimit(StkLimitParams{
+		TradeDate: tradeDate,
+	}, nil)
+}
+
+// CommonStkLimitFields 返回常用的涨跌停价格字段列表
+func (c *Client) CommonStkLimitFields() []string {
+	return []string{
+		StkLimitField.TSCode,
+		StkLimitField.TradeDate,
+		StkLimitField.UpLimit,
+		StkLimitField.DownLimit,
+	}
+}
@@ -0,0 +1,97 @@
+package client
+
+import (
+	"github.com/Premium-Platform/go-tushare/pkg/types"
+)
+
+// SuspendDParams 每日停复牌信息查询参数
+type SuspendDParams struct {
+	TSCode      string `json:"ts_code"`      // 股票代码（可输入多值）
+	TradeDate   string `json:"trade_date"`   // 停复牌日期
+	StartDate   string `json:"start_date"`   // 开始日期
+	EndDate     string `json:"end_date"`     // 结束日期
+	SuspendType string `json:"suspend_type"` // 停复牌类型：S=停牌 R=复牌
+}
+
+// SuspendDField 每日停复牌信息字段常量
+var SuspendDField = struct {
+	TSCode        string
+	TradeDate     string
+	SuspendTiming string
+	SuspendType   string
+}{
+	TSCode:        "ts_code",        // TS代码
+	TradeDate:     "trade_date",     // 停复牌日期
+	SuspendTiming: "suspend_timing", // 日内停牌时间段
+	SuspendType:   "suspend_type",   // 停复牌类型：S=停牌 R=复牌
+}
+
+// GetSuspendD 获取每日停复牌信息
+//
+// 接口参数：
+// - ts_code: 股票代码（可输入多值）
+// - trade_date: 停复牌日期
+// - start_date: 开始日期
+// - end_date: 结束日期
+// - suspend_type: 停复牌类型：S=停牌 R=复牌
+//
+// 返回字段：
+// - ts_code: TS代码
+// - trade_date: 停复牌日期
+// - suspend_timing: 日内停牌时间段（为空时全天停牌）
+// - suspend_type: 停复牌类型：S=停牌 R=复牌
+func (c *Client) GetSuspendD(params SuspendDParams, fields []string) (*types.DataFrame, error) {
+	// 构建请求参数
+	reqParams := map[string]interface{}{}
+
+	if params.TSCode != "" {
+		reqParams["ts_code"] = params.TSCode
+	}
+
+	if params.TradeDate != "" {
+		reqParams["trade_date"] = types.Date(params.TradeDate)
+	}
+
+	if params.StartDate != "" {
+		reqParams["start_date"] = types.Date(params.StartDate)
+	}
+
+	if params.EndDate != "" {
+		reqParams["end_date"] = types.Date(params.EndDate)
+	}
+
+	if params.SuspendType != "" {
+		reqParams["suspend_type"] = params.SuspendType
+	}
+
+	// 调用通用查询接口
+	return c.Query("suspend_d", reqParams, fields)
+}
+
+// GetStockSuspend 获取指定股票的停牌日期（简化接口）
+func (c *Client) GetStockSuspend(tsCode string, startDate string, endDate string) (*types.DataFrame, error) {
+	return c.GetSuspendD(SuspendDParams{
+		TSCode:      tsCode,
+		StartDate:   startDate,
+		EndDate:     endDate,
+		SuspendType: "S",
+	}, nil)
+}
+
+// GetDaySuspend 获取某一天全部停牌的股票（简化接口）
+func (c *Client) GetDaySuspend(tradeDate string) (*types.DataFrame, error) {
+	return c.GetSuspendD(SuspendDParams{
+		TradeDate:   tradeDate,
+		SuspendType: "S",
+	}, nil)
+}
+
+// CommonSuspendDFields 返回常用的停复牌信息字段列表
+func (c *Client) CommonSuspendDFields() []string {
+	return []string{
+		SuspendDField.TSCode,
+		SuspendDField.TradeDate,
+		SuspendDField.SuspendTiming,
+		SuspendDField.SuspendType,
+	}
+}
@@ -0,0 +1,171 @@
+package client
+
+import (
+	"time"
+
+	"github.com/Premium-Platform/go-tushare/pkg/cache"
+	"github.com/Premium-Platform/go-tushare/pkg/datasync"
+	"github.com/Premium-Platform/go-tushare/pkg/types"
+)
+
+// syncTolerance 按交易日同步的数据集行数校验允许的相对误差
+const syncTolerance = 0.02
+
+// SyncDatasets 返回全部可同步的数据集：日线、复权因子、每日指标、利润表、资产负债表、现金流量表
+func (c *Client) SyncDatasets() []datasync.Dataset {
+	return []datasync.Dataset{
+		c.DailyDataset(),
+		c.AdjFactorDataset(),
+		c.DailyBasicDataset(),
+		c.IncomeDataset(),
+		c.BalanceSheetDataset(),
+		c.CashflowDataset(),
+	}
+}
+
+// DailyDataset 按交易日同步全市场日线（daily）
+//
+// 预期行数为当日上市股票数减去全天停牌股票数
+func (c *Client) DailyDataset() datasync.Dataset {
+	return datasync.Dataset{
+		Name: "daily",
+		Keys: c.tradingDays,
+		Fetch: func(key string) (*types.DataFrame, error) {
+			return c.marketDaily(key)
+		},
+		Expect:    c.expectTrading,
+		Settled:   marketSettled,
+		Tolerance: syncTolerance,
+	}
+}
+
+// AdjFactorDataset 按交易日同步全市场复权因子（adj_factor）
+//
+// 停牌股票也有复权因子，预期行数为当日上市股票数
+func (c *Client) AdjFactorDataset() datasync.Dataset {
+	return datasync.Dataset{
+		Name: "adj_factor",
+		Keys: c.tradingDays,
+		Fetch: func(key string) (*types.DataFrame, error) {
+			return c.marketFactors(key)
+		},
+		Expect:    c.expectListed,
+		Settled:   marketSettled,
+		Tolerance: syncTolerance,
+	}
+}
+
+// DailyBasicDataset 按交易日同步全市场每日指标（daily_basic）
+//
+// 预期行数为当日上市股票数减去全天停牌股票数
+func (c *Client) DailyBasicDataset() datasync.Dataset {
+	return datasync.Dataset{
+		Name: "daily_basic",
+		Keys: c.tradingDays,
+		Fetch: func(key string) (*types.DataFrame, error) {
+			return c.queryAll("daily_basic", map[string]interface{}{"trade_date": key}, nil)
+		},
+		Expect:    c.expectTrading,
+		Settled:   marketSettled,
+		Tolerance: syncTolerance,
+	}
+}
+
+// IncomeDataset 按报告期同步全部公司的利润表（income_vip）
+//
+// 报告期结束一年内仍有新披露的数据，每次同步时重新获取
+func (c *Client) IncomeDataset() datasync.Dataset {
+	return c.reportDataset("income", "income_vip")
+}
+
+// BalanceSheetDataset 按报告期同步全部公司的资产负债表（balancesheet_vip）
+//
+// 报告期结束一年内仍有新披露的数据，每次同步时重新获取
+func (c *Client) BalanceSheetDataset() datasync.Dataset {
+	return c.reportDataset("balancesheet", "balancesheet_vip")
+}
+
+// CashflowDataset 按报告期同步全部公司的现金流量表（cashflow_vip）
+//
+// 报告期结束一年内仍有新披露的数据，每次同步时重新获取
+func (c *Client) CashflowDataset() datasync.Dataset {
+	return c.reportDataset("cashflow", "cashflow_vip")
+}
+
+// reportDataset 按报告期同步的财务数据集
+func (c *Client) reportDataset(name, apiName string) datasync.Dataset {
+	return datasync.Dataset{
+		Name: name,
+		Keys: reportPeriods,
+		Fetch: func(key string) (*types.DataFrame, error) {
+			return c.queryAll(apiName, map[string]interface{}{"period": key}, []string{})
+		},
+		Resync: func(key string) bool {
+			end, err := time.Parse("20060102", key)
+			return err != nil || time.Since(end) < cache.ReportSettled
+		},
+	}
+}
+
+// reportPeriods 获取日期区间内结束的报告期（季度末，升序）
+func reportPeriods(startDate, endDate string) ([]string, error) {
+	start, err := types.ParseDate(startDate)
+	if err != nil {
+		return nil, err
+	}
+	end, err := types.ParseDate(endDate)
+	if err != nil {
+		return nil, err
+	}
+	t, _ := start.Time()
+
+	p := types.NewPeriod(t.Year(), (int(t.Month())+2)/3)
+	periods := make([]string, 0)
+	for ; string(p.Date()) <= string(end); p = p.Next() {
+		if string(p.Date()) >= string(start) {
+			periods = append(periods, string(p))
+		}
+	}
+	return periods, nil
+}
+
+// marketSettled 交易日结束cache.MarketSettled之后行情数据视为不再变化
+func marketSettled(tradeDate string) bool {
+	t, err := time.ParseInLocation("20060102", tradeDate, time.Local)
+	return err == nil && time.Since(t.AddDate(0, 0, 1)) > cache.MarketSettled
+}
+
+// expectListed 预期行数：当日上市的股票数
+func (c *Client) expectListed(tradeDate string) (int, bool, error) {
+	codes, err := c.UniverseAt(tradeDate, UniverseFilter{})
+	if err != nil {
+		return 0, false, err
+	}
+	return len(codes), true, nil
+}
+
+// expectTrading 预期行数：当日上市的股票数减去全天停牌的股票数
+func (c *Client) expectTrading(tradeDate string) (int, bool, error) {
+	codes, err := c.UniverseAt(tradeDate, UniverseFilter{})
+	if err != nil {
+		return 0, false, err
+	}
+	suspend, err := c.GetDaySuspend(tradeDate)
+	if err != nil {
+		return 0, false, err
+	}
+
+	listed := make(map[string]bool, len(codes))
+	for _, code := range codes {
+		listed[code] = true
+	}
+	suspended := 0
+	for _, row := range suspend.Rows {
+		code, _ := row[SuspendDField.TSCode].(string)
+		timing, _ := row[SuspendDField.SuspendTiming].(string)
+		if timing == "" && listed[code] {
+			suspended++
+		}
+	}
+	return len(codes) - suspended, true, nil
+}
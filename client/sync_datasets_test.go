@@ -0,0 +1,59 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Premium-Platform/go-tushare/pkg/datasync"
+)
+
+// TestMarketDatasetsPaginated 按交易日获取的全市场数据超过单次返回上限时分页获取
+func TestMarketDatasetsPaginated(t *testing.T) {
+	var requests []RequestParams
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req RequestParams
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+		}
+		requests = append(requests, req)
+
+		// 第一页返回满页，第二页返回2行
+		n := 2
+		if offset, _ := req.Params["offset"].(float64); offset == 0 {
+			n = queryPageSize
+		}
+		items := make([][]interface{}, n)
+		for i := range items {
+			items[i] = []interface{}{"000001.SZ", "20240102", float64(i)}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"code": 0,
+			"data": map[string]interface{}{"fields": []string{"ts_code", "trade_date", "value"}, "items": items},
+		})
+	}))
+	defer srv.Close()
+
+	c := New("token")
+	c.SetAPIURL(srv.URL)
+
+	for _, ds := range []datasync.Dataset{c.DailyDataset(), c.AdjFactorDataset(), c.DailyBasicDataset()} {
+		requests = nil
+		df, err := ds.Fetch("20240102")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(df.Rows) != queryPageSize+2 {
+			t.Errorf("%s: got %d rows, want %d", ds.Name, len(df.Rows), queryPageSize+2)
+		}
+		if len(requests) != 2 {
+			t.Fatalf("%s: got %d requests, want 2", ds.Name, len(requests))
+		}
+		for i, req := range requests {
+			if req.APIName != ds.Name || req.Params["trade_date"] != "20240102" {
+				t.Errorf("%s request %d: %s %v", ds.Name, i, req.APIName, req.Params)
+			}
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Premium-Platform/go-tushare/client"
+	"github.com/Premium-Platform/go-tushare/pkg/calendar"
+	"github.com/Premium-Platform/go-tushare/pkg/types"
+)
+
+// 从trade_cal接口重新生成内置的交易日历快照
+//
+// 用法：TUSHARE_TOKEN=xxx go run ./cmd/calendar_snapshot -start 20000101 -end 20271231
+func main() {
+	start := flag.String("start", "20000101", "开始日期 YYYYMMDD")
+	end := flag.String("end", fmt.Sprintf("%d1231", time.Now().Year()+1), "结束日期 YYYYMMDD，默认为明年年底")
+	exchanges := flag.String("exchanges", "SSE,SZSE,CFFEX,SHFE,CZCE,DCE,INE,GFEX", "交易所列表，逗号分隔")
+	out := flag.String("out", "pkg/calendar/"+calendar.SnapshotFile, "输出文件")
+	flag.Parse()
+
+	// 从环境变量获取token
+	token := os.Getenv("TUSHARE_TOKEN")
+	if token == "" {
+		fmt.Println("请设置环境变量TUSHARE_TOKEN")
+		os.Exit(1)
+	}
+
+	startDate, err := types.ParseDate(*start)
+	if err != nil {
+		fmt.Printf("开始日期无效: %v\n", err)
+		os.Exit(1)
+	}
+	endDate, err := types.ParseDate(*end)
+	if err != nil {
+		fmt.Printf("结束日期无效: %v\n", err)
+		os.Exit(1)
+	}
+
+	// 创建客户端
+	cli := client.New(token)
+
+	calendars := make(map[string][]calendar.Day)
+	for _, exchange := range strings.Split(*exchanges, ",") {
+		exchange = strings.TrimSpace(exchange)
+		if exchange == "" {
+			continue
+		}
+
+		// 按年获取，避免单次请求超过接口的行数限制
+		days := make([]calendar.Day, 0)
+		for _, r := range yearRanges(string(startDate), string(endDate)) {
+			df, err := cli.GetTradeCal(client.TradeCalParams{
+				Exchange:  exchange,
+				StartDate: r[0],
+				EndDate:   r[1],
+			}, []string{client.TradeCalField.CalDate, client.TradeCalField.IsOpen})
+			if err != nil {
+				fmt.Printf("获取%s交易日历失败: %v\n", exchange, err)
+				os.Exit(1)
+			}
+			for _, row := range df.Rows {
+				date, _ := row[client.TradeCalField.CalDate].(string)
+				open, _ := types.ToFloat64(row[client.TradeCalField.IsOpen])
+				days = append(days, calendar.Day{Date: date, Open: open == 1})
+			}
+		}
+		calendars[exchange] = days
+		fmt.Printf("%s: %d天\n", exchange, len(days))
+	}
+
+	var buf bytes.Buffer
+	if err := calendar.WriteSnapshot(&buf, calendars); err != nil {
+		fmt.Printf("生成快照失败: %v\n", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(*out, buf.Bytes(), 0644); err != nil {
+		fmt.Printf("写入快照失败: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("已写入%s\n", *out)
+}
+
+// yearRanges 将日期区间按自然年拆分
+func yearRanges(start, end string) [][2]string {
+	ranges := make([][2]string, 0)
+	for from := start; from <= end; {
+		to := from[:4] + "1231"
+		if to > end {
+			to = end
+		}
+		ranges = append(ranges, [2]string{from, to})
+		year, err := strconv.Atoi(from[:4])
+		if err != nil {
+			break
+		}
+		from = strconv.Itoa(year+1) + "0101"
+	}
+	return ranges
+}
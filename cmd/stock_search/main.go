@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Premium-Platform/go-tushare/client"
+	"github.com/Premium-Platform/go-tushare/pkg/search"
+)
+
+// 按代码、名称、拼音首字母或曾用名搜索股票
+//
+// 用法：TUSHARE_TOKEN=xxx go run ./cmd/stock_search payh 平安 000001
+// 不带查询参数时从标准输入逐行读取查询。索引保存在-index指定的文件中，-refresh重新从接口构建。
+func main() {
+	limit := flag.Int("limit", 10, "每个查询返回的最大结果数")
+	indexFile := flag.String("index", "stock_search.json", "本地索引文件")
+	refresh := flag.Bool("refresh", false, "重新从接口构建索引")
+	flag.Parse()
+
+	ix, err := loadIndex(*indexFile, *refresh)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	if flag.NArg() > 0 {
+		for _, query := range flag.Args() {
+			printResults(query, ix.Search(query, *limit))
+		}
+		return
+	}
+
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		if query := strings.TrimSpace(scanner.Text()); query != "" {
+			printResults(query, ix.Search(query, *limit))
+		}
+	}
+}
+
+// loadIndex 读取本地索引，不存在或需要刷新时从接口构建并保存
+func loadIndex(path string, refresh bool) (*search.Index, error) {
+	if !refresh {
+		if f, err := os.Open(path); err == nil {
+			defer f.Close()
+			return search.Load(f)
+		}
+	}
+
+	// 从环境变量获取token
+	token := os.Getenv("TUSHARE_TOKEN")
+	if token == "" {
+		return nil, fmt.Errorf("请设置环境变量TUSHARE_TOKEN")
+	}
+
+	ix, err := client.New(token).BuildSearchIndex()
+	if err != nil {
+		return nil, fmt.Errorf("构建索引失败: %v", err)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("保存索引失败: %v", err)
+	}
+	defer f.Close()
+	if err := ix.Save(f); err != nil {
+		return nil, fmt.Errorf("保存索引失败: %v", err)
+	}
+	fmt.Printf("已构建索引: %d只股票, 保存至%s\n", ix.Len(), path)
+	return ix, nil
+}
+
+// printResults 打印搜索结果
+func printResults(query string, results []search.Result) {
+	fmt.Printf("=== %s: %d条结果 ===\n", query, len(results))
+	for _, r := range results {
+		note := ""
+		if r.Historical {
+			note = " 曾用名:" + r.Matched
+		}
+		if r.ListStatus == "D" {
+			note += " (已退市)"
+		}
+		fmt.Printf("%-10s %-8s %-8s %s%s\n", r.TSCode, r.Name, r.Cnspell, r.Kind, note)
+	}
+}
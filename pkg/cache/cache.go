@@ -0,0 +1,146 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync/atomic"
+	"time"
+)
+
+// Store 缓存存储后端
+type Store interface {
+	// Get 读取缓存数据及写入时间，不存在时ok为false
+	Get(key string) (data []byte, created time.Time, ok bool, err error)
+	// Set 写入缓存数据
+	Set(key string, data []byte) error
+	// Delete 删除缓存数据，不存在时不返回错误
+	Delete(key string) error
+}
+
+// Stats 缓存统计
+type Stats struct {
+	Hits      int64 // 命中次数
+	Misses    int64 // 未命中（含已过期）次数
+	Expired   int64 // 已过期次数
+	Bypasses  int64 // 跳过缓存的查询次数（含策略不缓存的接口）
+	Refreshes int64 // 强制刷新次数
+	Writes    int64 // 写入次数
+	Errors    int64 // 存储读写错误次数
+}
+
+// HitRate 命中率，没有查询时为0
+func (s Stats) HitRate() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+// Cache 接口查询结果缓存
+//
+// 以接口名、规范化后的参数和字段列表作为键，按Policy确定各接口数据的有效期
+type Cache struct {
+	// 计数器放在开头，保证32位平台上原子操作的对齐
+	hits, misses, expired, bypasses, refreshes, writes, errors int64
+
+	store  Store
+	policy *Policy
+	now    func() time.Time
+}
+
+// New 创建缓存，policy为nil时使用DefaultPolicy
+func New(store Store, policy *Policy) *Cache {
+	if policy == nil {
+		policy = DefaultPolicy()
+	}
+	return &Cache{store: store, policy: policy, now: time.Now}
+}
+
+// Policy 获取缓存策略
+func (c *Cache) Policy() *Policy {
+	return c.policy
+}
+
+// Key 计算缓存键：接口名/参数和字段的SHA-256
+//
+// 参数按键名排序序列化，字段保持顺序（决定返回列的顺序）
+func Key(apiName string, params map[string]interface{}, fields []string) string {
+	data, _ := json.Marshal(struct {
+		Params map[string]interface{} `json:"params"`
+		Fields []string               `json:"fields"`
+	}{params, fields})
+	sum := sha256.Sum256(data)
+	return apiName + "/" + hex.EncodeToString(sum[:])
+}
+
+// Get 读取未过期的缓存数据
+func (c *Cache) Get(apiName string, params map[string]interface{}, fields []string) ([]byte, bool) {
+	ttl := c.policy.TTL(apiName, params, c.now())
+	if ttl == 0 {
+		atomic.AddInt64(&c.bypasses, 1)
+		return nil, false
+	}
+
+	data, created, ok, err := c.store.Get(Key(apiName, params, fields))
+	if err != nil {
+		atomic.AddInt64(&c.errors, 1)
+		atomic.AddInt64(&c.misses, 1)
+		return nil, false
+	}
+	if !ok {
+		atomic.AddInt64(&c.misses, 1)
+		return nil, false
+	}
+	if ttl != Forever && c.now().Sub(created) > ttl {
+		atomic.AddInt64(&c.expired, 1)
+		atomic.AddInt64(&c.misses, 1)
+		return nil, false
+	}
+	atomic.AddInt64(&c.hits, 1)
+	return data, true
+}
+
+// Set 写入缓存数据，策略不缓存的接口忽略
+func (c *Cache) Set(apiName string, params map[string]interface{}, fields []string, data []byte) error {
+	if c.policy.TTL(apiName, params, c.now()) == 0 {
+		return nil
+	}
+	if err := c.store.Set(Key(apiName, params, fields), data); err != nil {
+		atomic.AddInt64(&c.errors, 1)
+		return err
+	}
+	atomic.AddInt64(&c.writes, 1)
+	return nil
+}
+
+// Bypass 记录一次跳过缓存的查询
+func (c *Cache) Bypass() {
+	atomic.AddInt64(&c.bypasses, 1)
+}
+
+// Refresh 记录一次强制刷新
+func (c *Cache) Refresh() {
+	atomic.AddInt64(&c.refreshes, 1)
+}
+
+// Stats 获取缓存统计
+func (c *Cache) Stats() Stats {
+	return Stats{
+		Hits:      atomic.LoadInt64(&c.hits),
+		Misses:    atomic.LoadInt64(&c.misses),
+		Expired:   atomic.LoadInt64(&c.expired),
+		Bypasses:  atomic.LoadInt64(&c.bypasses),
+		Refreshes: atomic.LoadInt64(&c.refreshes),
+		Writes:    atomic.LoadInt64(&c.writes),
+		Errors:    atomic.LoadInt64(&c.errors),
+	}
+}
+
+// ResetStats 清零缓存统计
+func (c *Cache) ResetStats() {
+	for _, p := range []*int64{&c.hits, &c.misses, &c.expired, &c.bypasses, &c.refreshes, &c.writes, &c.errors} {
+		atomic.StoreInt64(p, 0)
+	}
+}
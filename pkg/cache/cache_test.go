@@ -0,0 +1,119 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+// newTestCache 创建使用文件存储和可控时钟的缓存
+func newTestCache(t *testing.T, policy *Policy) (*Cache, *time.Time) {
+	t.Helper()
+	c := New(newTestFileStore(t), policy)
+	now := time.Now()
+	c.now = func() time.Time { return now }
+	return c, &now
+}
+
+// TestCacheGetCounters 命中、未命中和过期分别计数，过期同时计为未命中
+func TestCacheGetCounters(t *testing.T) {
+	policy := &Policy{}
+	policy.Set("trade_cal", Rule{TTL: time.Hour})
+	c, now := newTestCache(t, policy)
+	params := map[string]interface{}{"exchange": "SSE"}
+
+	if _, ok := c.Get("trade_cal", params, nil); ok {
+		t.Fatal("Get before Set: want miss")
+	}
+	if err := c.Set("trade_cal", params, nil, []byte(`{}`)); err != nil {
+		t.Fatal(err)
+	}
+	if data, ok := c.Get("trade_cal", params, nil); !ok || string(data) != `{}` {
+		t.Fatalf("Get = %s, %v, want hit", data, ok)
+	}
+	// 字段列表不同视为不同的查询
+	if _, ok := c.Get("trade_cal", params, []string{"cal_date"}); ok {
+		t.Error("Get with other fields: want miss")
+	}
+
+	*now = now.Add(2 * time.Hour)
+	if _, ok := c.Get("trade_cal", params, nil); ok {
+		t.Error("Get after TTL: want expired")
+	}
+
+	want := Stats{Hits: 1, Misses: 3, Expired: 1, Writes: 1}
+	if stats := c.Stats(); stats != want {
+		t.Errorf("stats = %+v, want %+v", stats, want)
+	}
+	if rate := c.Stats().HitRate(); rate != 0.25 {
+		t.Errorf("hit rate = %v, want 0.25", rate)
+	}
+
+	c.ResetStats()
+	if stats := c.Stats(); stats != (Stats{}) {
+		t.Errorf("stats after reset = %+v, want zero", stats)
+	}
+}
+
+// TestCacheBypass 策略不缓存的接口既不读也不写
+func TestCacheBypass(t *testing.T) {
+	c, _ := newTestCache(t, DefaultPolicy())
+	params := map[string]interface{}{"ts_code": "000001.SZ"}
+
+	if err := c.Set("realtime_quote", params, nil, []byte(`{}`)); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := c.Get("realtime_quote", params, nil); ok {
+		t.Error("Get uncached api: want miss")
+	}
+	if stats := c.Stats(); stats.Bypasses != 1 || stats.Writes != 0 || stats.Misses != 0 {
+		t.Errorf("stats = %+v, want 1 bypass and no writes or misses", stats)
+	}
+}
+
+// TestCacheSettled 截止日期早于SettledAfter的数据永久有效
+func TestCacheSettled(t *testing.T) {
+	c, now := newTestCache(t, DefaultPolicy())
+	*now = time.Date(2024, 3, 1, 15, 0, 0, 0, time.Local)
+
+	settled := map[string]interface{}{"ts_code": "000001.SZ", "end_date": "20240226"}
+	recent := map[string]interface{}{"ts_code": "000001.SZ", "end_date": "20240301"}
+	for _, params := range []map[string]interface{}{settled, recent} {
+		if err := c.Set("daily", params, nil, []byte(`{}`)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	*now = now.AddDate(1, 0, 0)
+	if _, ok := c.Get("daily", settled, nil); !ok {
+		t.Error("settled query: want hit a year later")
+	}
+	if _, ok := c.Get("daily", recent, nil); !ok {
+		t.Error("recent query: want hit once it has settled")
+	}
+}
+
+func TestPolicyTTL(t *testing.T) {
+	p := DefaultPolicy()
+	now := time.Date(2024, 3, 1, 15, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		api    string
+		params map[string]interface{}
+		want   time.Duration
+	}{
+		{"daily", map[string]interface{}{"end_date": "20240226"}, Forever},
+		{"daily", map[string]interface{}{"end_date": "20240228"}, RecentTTL},
+		{"daily", map[string]interface{}{"trade_date": "20240301"}, RecentTTL},
+		{"daily", map[string]interface{}{"ts_code": "000001.SZ"}, RecentTTL},
+		{"stk_mins", map[string]interface{}{"end_date": "2024-02-20 15:00:00"}, Forever},
+		{"income", map[string]interface{}{"period": "20221231"}, Forever},
+		{"income", map[string]interface{}{"period": "20231231"}, RecentTTL},
+		{"trade_cal", map[string]interface{}{"end_date": "20000101"}, DailyTTL},
+		{"realtime_quote", nil, 0},
+	}
+	for _, tt := range tests {
+		if got := p.TTL(tt.api, tt.params, now); got != tt.want {
+			t.Errorf("TTL(%s, %v) = %v, want %v", tt.api, tt.params, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package cache
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tsError "github.com/Premium-Platform/go-tushare/pkg/errors"
+)
+
+// FileStore 文件系统缓存存储
+//
+// 每个缓存键保存为一个JSON文件：目录/接口名/哈希.json，写入时先写临时文件再重命名，避免读到不完整的数据
+type FileStore struct {
+	dir string
+}
+
+// fileEntry 缓存文件内容
+type fileEntry struct {
+	Created time.Time       `json:"created"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// NewFileStore 创建文件系统缓存存储，目录不存在时自动创建
+func NewFileStore(dir string) (*FileStore, error) {
+	if dir == "" {
+		return nil, tsError.Wrap(tsError.ErrInvalidParameter, "cache dir is required")
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, tsError.Wrapf(err, "failed to create cache dir %s", dir)
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+// Dir 获取缓存目录
+func (s *FileStore) Dir() string {
+	return s.dir
+}
+
+// path 缓存键对应的文件路径
+func (s *FileStore) path(key string) (string, error) {
+	if key == "" || strings.Contains(key, "..") || strings.HasPrefix(key, "/") {
+		return "", tsError.Wrapf(tsError.ErrInvalidParameter, "invalid cache key %q", key)
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(key)+".json"), nil
+}
+
+// Get 读取缓存数据
+func (s *FileStore) Get(key string) ([]byte, time.Time, bool, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, time.Time{}, false, err
+	}
+	content, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, time.Time{}, false, nil
+	}
+	if err != nil {
+		return nil, time.Time{}, false, tsError.Wrapf(err, "failed to read cache %s", key)
+	}
+
+	var entry fileEntry
+	if err := json.Unmarshal(content, &entry); err != nil {
+		return nil, time.Time{}, false, tsError.Wrapf(err, "failed to decode cache %s", key)
+	}
+	return entry.Data, entry.Created, true, nil
+}
+
+// Set 写入缓存数据
+func (s *FileStore) Set(key string, data []byte) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	content, err := json.Marshal(fileEntry{Created: time.Now(), Data: data})
+	if err != nil {
+		return tsError.Wrapf(err, "failed to encode cache %s", key)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return tsError.Wrapf(err, "failed to create cache dir for %s", key)
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return tsError.Wrapf(err, "failed to write cache %s", key)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return tsError.Wrapf(err, "failed to write cache %s", key)
+	}
+	if err := tmp.Close(); err != nil {
+		return tsError.Wrapf(err, "failed to write cache %s", key)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return tsError.Wrapf(err, "failed to write cache %s", key)
+	}
+	return nil
+}
+
+// Delete 删除缓存数据
+func (s *FileStore) Delete(key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return tsError.Wrapf(err, "failed to delete cache %s", key)
+	}
+	return nil
+}
+
+// Clear 删除全部缓存文件
+func (s *FileStore) Clear() error {
+	entries, err := ioutil.ReadDir(s.dir)
+	if err != nil {
+		return tsError.Wrapf(err, "failed to read cache dir %s", s.dir)
+	}
+	for _, e := range entries {
+		if err := os.RemoveAll(filepath.Join(s.dir, e.Name())); err != nil {
+			return tsError.Wrapf(err, "failed to clear cache dir %s", s.dir)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package cache
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tsError "github.com/Premium-Platform/go-tushare/pkg/errors"
+)
+
+// newTestFileStore 在临时目录中创建文件缓存存储
+func newTestFileStore(t *testing.T) *FileStore {
+	t.Helper()
+	s, err := NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestFileStoreRoundTrip(t *testing.T) {
+	s := newTestFileStore(t)
+	key := Key("daily", map[string]interface{}{"trade_date": "20240102"}, nil)
+
+	if _, _, ok, err := s.Get(key); ok || err != nil {
+		t.Fatalf("Get missing key = %v, %v, want miss without error", ok, err)
+	}
+
+	data := []byte(`{"fields":["ts_code"],"items":[["000001.SZ"]]}`)
+	if err := s.Set(key, data); err != nil {
+		t.Fatal(err)
+	}
+	got, created, ok, err := s.Get(key)
+	if err != nil || !ok {
+		t.Fatalf("Get = %v, %v, want hit", ok, err)
+	}
+	if string(got) != string(data) {
+		t.Errorf("data = %s, want %s", got, data)
+	}
+	if created.IsZero() {
+		t.Error("created time not recorded")
+	}
+
+	// 缓存文件按接口名分目录保存
+	if _, err := os.Stat(filepath.Join(s.Dir(), filepath.FromSlash(key)+".json")); err != nil {
+		t.Errorf("cache file: %v", err)
+	}
+
+	if err := s.Delete(key); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, ok, _ := s.Get(key); ok {
+		t.Error("Get after Delete: want miss")
+	}
+	if err := s.Delete(key); err != nil {
+		t.Errorf("Delete missing key: %v", err)
+	}
+}
+
+// TestFileStoreAtomicWrite 覆盖写入通过临时文件重命名完成，不留下临时文件
+func TestFileStoreAtomicWrite(t *testing.T) {
+	s := newTestFileStore(t)
+	key := "daily/abc"
+
+	for _, data := range []string{`{"v":1}`, `{"v":2}`} {
+		if err := s.Set(key, []byte(data)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got, _, _, err := s.Get(key)
+	if err != nil || string(got) != `{"v":2}` {
+		t.Errorf("Get = %s, %v, want the second write", got, err)
+	}
+
+	entries, err := ioutil.ReadDir(filepath.Join(s.Dir(), "daily"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), ".tmp-") {
+			t.Errorf("temporary file %s left behind", e.Name())
+		}
+	}
+	if len(entries) != 1 {
+		t.Errorf("got %d files, want 1", len(entries))
+	}
+
+	// 损坏的缓存文件返回错误而不是数据
+	if err := ioutil.WriteFile(filepath.Join(s.Dir(), "daily", "bad.json"), []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, ok, err := s.Get("daily/bad"); ok || err == nil {
+		t.Errorf("Get corrupt file = %v, %v, want error", ok, err)
+	}
+}
+
+func TestFileStoreClear(t *testing.T) {
+	s := newTestFileStore(t)
+	for _, key := range []string{"daily/a", "daily/b", "trade_cal/c"} {
+		if err := s.Set(key, []byte(`{}`)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := s.Clear(); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := ioutil.ReadDir(s.Dir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("got %d entries after Clear, want 0", len(entries))
+	}
+	if _, _, ok, _ := s.Get("daily/a"); ok {
+		t.Error("Get after Clear: want miss")
+	}
+	// 清空后仍可继续写入
+	if err := s.Set("daily/a", []byte(`{}`)); err != nil {
+		t.Errorf("Set after Clear: %v", err)
+	}
+}
+
+// TestFileStoreInvalidKey 拒绝可能写到缓存目录之外的键
+func TestFileStoreInvalidKey(t *testing.T) {
+	s := newTestFileStore(t)
+	for _, key := range []string{"", "../escape", "daily/../../escape", "/etc/passwd"} {
+		if err := s.Set(key, []byte(`{}`)); tsError.Cause(err) != tsError.ErrInvalidParameter {
+			t.Errorf("Set(%q) error = %v, want ErrInvalidParameter", key, err)
+		}
+		if _, _, _, err := s.Get(key); tsError.Cause(err) != tsError.ErrInvalidParameter {
+			t.Errorf("Get(%q) error = %v, want ErrInvalidParameter", key, err)
+		}
+		if err := s.Delete(key); tsError.Cause(err) != tsError.ErrInvalidParameter {
+			t.Errorf("Delete(%q) error = %v, want ErrInvalidParameter", key, err)
+		}
+	}
+
+	if _, err := NewFileStore(""); tsError.Cause(err) != tsError.ErrInvalidParameter {
+		t.Errorf("NewFileStore(\"\") error = %v, want ErrInvalidParameter", err)
+	}
+}
@@ -0,0 +1,193 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	tsError "github.com/Premium-Platform/go-tushare/pkg/errors"
+)
+
+// MemoryStats 进程内缓存统计
+type MemoryStats struct {
+	Hits      int64 // 命中次数
+	Misses    int64 // 未命中（含已过期）次数
+	Shared    int64 // 合并到进行中的相同查询的次数
+	Evictions int64 // 超出容量淘汰的条目数
+	Len       int   // 当前条目数
+}
+
+// memoryItem LRU链表中的条目
+type memoryItem struct {
+	key     string
+	value   interface{}
+	expires time.Time
+}
+
+// flight 进行中的查询
+type flight struct {
+	wg    sync.WaitGroup
+	value interface{}
+	err   error
+}
+
+// Memory 进程内缓存：容量受限的LRU、过期时间，以及相同键的并发查询合并（singleflight）
+//
+// Memory只保存值本身，调用方需要自行返回副本，避免缓存的值被修改
+type Memory struct {
+	// 计数器放在开头，保证32位平台上原子操作的对齐
+	hits, misses, shared, evictions int64
+
+	mu       sync.Mutex
+	capacity int
+	ttl      time.Duration
+	ll       *list.List               // 最近使用的条目在前
+	items    map[string]*list.Element // 键 -> 链表条目
+	flights  map[string]*flight       // 键 -> 进行中的查询
+	now      func() time.Time
+}
+
+// NewMemory 创建进程内缓存，capacity为最大条目数，ttl<=0表示不过期
+func NewMemory(capacity int, ttl time.Duration) (*Memory, error) {
+	if capacity <= 0 {
+		return nil, tsError.Wrapf(tsError.ErrInvalidParameter, "invalid memory cache capacity %d", capacity)
+	}
+	return &Memory{
+		capacity: capacity,
+		ttl:      ttl,
+		ll:       list.New(),
+		items:    make(map[string]*list.Element),
+		flights:  make(map[string]*flight),
+		now:      time.Now,
+	}, nil
+}
+
+// Get 读取未过期的值
+func (m *Memory) Get(key string) (interface{}, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	elem, ok := m.items[key]
+	if !ok {
+		atomic.AddInt64(&m.misses, 1)
+		return nil, false
+	}
+	item := elem.Value.(*memoryItem)
+	if !item.expires.IsZero() && m.now().After(item.expires) {
+		m.removeElement(elem)
+		atomic.AddInt64(&m.misses, 1)
+		return nil, false
+	}
+	m.ll.MoveToFront(elem)
+	atomic.AddInt64(&m.hits, 1)
+	return item.value, true
+}
+
+// Add 写入值，超出容量时淘汰最久未使用的条目
+func (m *Memory) Add(key string, value interface{}) {
+	m.AddTTL(key, value, Forever)
+}
+
+// AddTTL 按数据本身的有效期写入值，实际有效期取ttl和进程内缓存有效期中较短的一个
+//
+// ttl与Policy.TTL含义相同：0表示不缓存（同时删除已有条目），Forever表示只受进程内缓存有效期限制
+func (m *Memory) AddTTL(key string, value interface{}, ttl time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if ttl == 0 {
+		if elem, ok := m.items[key]; ok {
+			m.removeElement(elem)
+		}
+		return
+	}
+	if ttl < 0 || (m.ttl > 0 && m.ttl < ttl) {
+		ttl = m.ttl
+	}
+
+	var expires time.Time
+	if ttl > 0 {
+		expires = m.now().Add(ttl)
+	}
+	if elem, ok := m.items[key]; ok {
+		item := elem.Value.(*memoryItem)
+		item.value, item.expires = value, expires
+		m.ll.MoveToFront(elem)
+		return
+	}
+
+	m.items[key] = m.ll.PushFront(&memoryItem{key: key, value: value, expires: expires})
+	for m.ll.Len() > m.capacity {
+		m.removeElement(m.ll.Back())
+		atomic.AddInt64(&m.evictions, 1)
+	}
+}
+
+// Remove 删除条目
+func (m *Memory) Remove(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if elem, ok := m.items[key]; ok {
+		m.removeElement(elem)
+	}
+}
+
+// Purge 删除全部条目
+func (m *Memory) Purge() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.ll.Init()
+	m.items = make(map[string]*list.Element)
+}
+
+// Len 获取条目数（含尚未清理的过期条目）
+func (m *Memory) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.ll.Len()
+}
+
+// Do 执行查询，同一个键同时只执行一次，其余调用等待并共享结果
+//
+// shared表示结果是否来自其他调用发起的查询；fn的结果不会自动写入缓存
+func (m *Memory) Do(key string, fn func() (interface{}, error)) (value interface{}, shared bool, err error) {
+	m.mu.Lock()
+	if f, ok := m.flights[key]; ok {
+		m.mu.Unlock()
+		atomic.AddInt64(&m.shared, 1)
+		f.wg.Wait()
+		return f.value, true, f.err
+	}
+	f := &flight{}
+	f.wg.Add(1)
+	m.flights[key] = f
+	m.mu.Unlock()
+
+	defer func() {
+		m.mu.Lock()
+		delete(m.flights, key)
+		m.mu.Unlock()
+		f.wg.Done()
+	}()
+
+	f.value, f.err = fn()
+	return f.value, false, f.err
+}
+
+// Stats 获取缓存统计
+func (m *Memory) Stats() MemoryStats {
+	return MemoryStats{
+		Hits:      atomic.LoadInt64(&m.hits),
+		Misses:    atomic.LoadInt64(&m.misses),
+		Shared:    atomic.LoadInt64(&m.shared),
+		Evictions: atomic.LoadInt64(&m.evictions),
+		Len:       m.Len(),
+	}
+}
+
+// removeElement 删除链表条目，调用方需持有锁
+func (m *Memory) removeElement(elem *list.Element) {
+	m.ll.Remove(elem)
+	delete(m.items, elem.Value.(*memoryItem).key)
+}
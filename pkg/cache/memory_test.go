@@ -0,0 +1,147 @@
+package cache
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// newTestMemory 创建使用可控时钟的进程内缓存
+func newTestMemory(t *testing.T, capacity int, ttl time.Duration) (*Memory, *time.Time) {
+	t.Helper()
+	m, err := NewMemory(capacity, ttl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2024, 1, 2, 9, 30, 0, 0, time.UTC)
+	m.now = func() time.Time { return now }
+	return m, &now
+}
+
+func TestMemoryEviction(t *testing.T) {
+	m, _ := newTestMemory(t, 2, 0)
+	m.Add("a", 1)
+	m.Add("b", 2)
+	m.Get("a") // a最近使用，b最久未使用
+	m.Add("c", 3)
+
+	if _, ok := m.Get("b"); ok {
+		t.Error("b should have been evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok := m.Get(key); !ok {
+			t.Errorf("%s should still be cached", key)
+		}
+	}
+	if stats := m.Stats(); stats.Evictions != 1 || stats.Len != 2 {
+		t.Errorf("stats = %+v, want 1 eviction and 2 entries", stats)
+	}
+
+	if _, err := NewMemory(0, 0); err == nil {
+		t.Error("NewMemory(0) should fail")
+	}
+}
+
+func TestMemoryTTL(t *testing.T) {
+	m, now := newTestMemory(t, 10, 10*time.Minute)
+
+	m.Add("memory", 1)                 // 进程内缓存有效期10分钟
+	m.AddTTL("policy", 2, time.Minute) // 策略有效期更短
+	m.AddTTL("forever", 3, Forever)    // 只受进程内缓存有效期限制
+	m.AddTTL("longer", 4, time.Hour)   // 策略有效期更长
+	m.AddTTL("none", 5, 0)             // 策略不缓存
+
+	if _, ok := m.Get("none"); ok {
+		t.Error("TTL 0 should not be cached")
+	}
+
+	*now = now.Add(2 * time.Minute)
+	if _, ok := m.Get("policy"); ok {
+		t.Error("policy TTL should expire after 1 minute")
+	}
+	if _, ok := m.Get("memory"); !ok {
+		t.Error("memory TTL should not expire after 2 minutes")
+	}
+
+	*now = now.Add(9 * time.Minute)
+	for _, key := range []string{"memory", "forever", "longer"} {
+		if _, ok := m.Get(key); ok {
+			t.Errorf("%s should expire with the memory TTL", key)
+		}
+	}
+
+	// TTL 0删除已有条目
+	m.Add("stale", 1)
+	m.AddTTL("stale", 2, 0)
+	if _, ok := m.Get("stale"); ok {
+		t.Error("AddTTL with 0 should remove the entry")
+	}
+
+	// 进程内缓存不过期时使用策略有效期
+	m2, now2 := newTestMemory(t, 10, 0)
+	m2.AddTTL("k", 1, time.Hour)
+	*now2 = now2.Add(2 * time.Hour)
+	if _, ok := m2.Get("k"); ok {
+		t.Error("policy TTL should apply when memory TTL is 0")
+	}
+}
+
+func TestMemoryDoShared(t *testing.T) {
+	m, _ := newTestMemory(t, 10, 0)
+
+	const followers = 8
+	release := make(chan struct{})
+	calls := 0
+	leaderDone := make(chan struct{})
+	go func() {
+		defer close(leaderDone)
+		v, shared, err := m.Do("k", func() (interface{}, error) {
+			calls++
+			<-release
+			return 42, nil
+		})
+		if v != 42 || shared || err != nil {
+			t.Errorf("leader: %v, %v, %v", v, shared, err)
+		}
+	}()
+
+	// 等待leader开始执行后再发起相同的查询
+	for {
+		m.mu.Lock()
+		_, running := m.flights["k"]
+		m.mu.Unlock()
+		if running {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < followers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, shared, err := m.Do("k", func() (interface{}, error) {
+				t.Error("follower should not run fn")
+				return nil, nil
+			})
+			if v != 42 || !shared || err != nil {
+				t.Errorf("follower: %v, %v, %v", v, shared, err)
+			}
+		}()
+	}
+	for m.Stats().Shared < followers {
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+	<-leaderDone
+
+	if calls != 1 {
+		t.Errorf("fn called %d times, want 1", calls)
+	}
+	// Do不写入缓存，完成后再次调用重新执行
+	if _, shared, _ := m.Do("k", func() (interface{}, error) { return 1, nil }); shared {
+		t.Error("Do after completion should not be shared")
+	}
+}
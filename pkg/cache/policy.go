@@ -0,0 +1,116 @@
+package cache
+
+import (
+	"strings"
+	"time"
+)
+
+// Forever 永久有效
+const Forever time.Duration = -1
+
+// Rule 接口的缓存规则
+type Rule struct {
+	TTL time.Duration // 有效期，0不缓存，Forever永久有效
+
+	// SettledAfter 大于0时，查询截止日期早于当前时间减去该时长的数据视为不再变化，永久有效；
+	// 截止日期依次取end_date、trade_date、period参数，没有截止日期的查询使用TTL
+	SettledAfter time.Duration
+}
+
+// Policy 各接口的缓存策略
+type Policy struct {
+	Rules   map[string]Rule // 接口名 -> 缓存规则
+	Default Rule            // 未配置接口的规则
+}
+
+// 默认策略的有效期
+const (
+	// RecentTTL 包含最近数据的行情查询、最新报告期的财务查询
+	RecentTTL = time.Hour
+	// DailyTTL 交易日历、股票列表等基础数据
+	DailyTTL = 24 * time.Hour
+	// MarketSettled 行情数据在交易日结束两天后视为不再变化（覆盖复权因子等次日发布的数据）
+	MarketSettled = 48 * time.Hour
+	// ReportSettled 财务报告期结束一年后视为不再变化（覆盖年报的披露期）
+	ReportSettled = 366 * 24 * time.Hour
+)
+
+// historyAPIs 历史行情类接口
+var historyAPIs = []string{
+	"daily", "weekly", "monthly", "adj_factor", "fund_adj", "daily_basic", "stk_limit", "suspend_d",
+	"stk_mins", "idx_mins", "ft_mins", "index_daily", "index_weekly", "index_monthly", "index_weight",
+	"fut_daily", "fut_mapping", "fund_daily", "opt_daily", "cb_daily",
+}
+
+// reportAPIs 财务报告类接口
+var reportAPIs = []string{
+	"income", "balancesheet", "cashflow", "income_vip", "balancesheet_vip", "cashflow_vip",
+	"forecast", "express", "fina_indicator",
+}
+
+// referenceAPIs 按天更新的基础数据接口
+var referenceAPIs = []string{
+	"trade_cal", "stock_basic", "namechange", "hs_const", "index_basic", "stock_company", "new_share", "fut_basic",
+}
+
+// DefaultPolicy 默认缓存策略
+//
+// 历史行情截止日期两天前的数据永久有效，否则1小时；财务报告期一年前的数据永久有效，否则1小时；
+// 交易日历和股票列表等基础数据1天；其他接口不缓存
+func DefaultPolicy() *Policy {
+	p := &Policy{Rules: make(map[string]Rule)}
+	for _, api := range historyAPIs {
+		p.Rules[api] = Rule{TTL: RecentTTL, SettledAfter: MarketSettled}
+	}
+	for _, api := range reportAPIs {
+		p.Rules[api] = Rule{TTL: RecentTTL, SettledAfter: ReportSettled}
+	}
+	for _, api := range referenceAPIs {
+		p.Rules[api] = Rule{TTL: DailyTTL}
+	}
+	return p
+}
+
+// Set 设置接口的缓存规则
+func (p *Policy) Set(apiName string, rule Rule) {
+	if p.Rules == nil {
+		p.Rules = make(map[string]Rule)
+	}
+	p.Rules[apiName] = rule
+}
+
+// TTL 获取查询结果的有效期
+func (p *Policy) TTL(apiName string, params map[string]interface{}, now time.Time) time.Duration {
+	rule, ok := p.Rules[apiName]
+	if !ok {
+		rule = p.Default
+	}
+	if rule.SettledAfter > 0 {
+		if end, ok := queryEnd(params); ok && now.Sub(end) > rule.SettledAfter {
+			return Forever
+		}
+	}
+	return rule.TTL
+}
+
+// queryEnd 获取查询的截止日期
+func queryEnd(params map[string]interface{}) (time.Time, bool) {
+	for _, key := range []string{"end_date", "trade_date", "period"} {
+		s, _ := params[key].(string)
+		if s == "" {
+			continue
+		}
+		// 分钟线使用YYYY-MM-DD HH:MM:SS格式
+		s = strings.ReplaceAll(s, "-", "")
+		if len(s) < 8 {
+			return time.Time{}, false
+		}
+		t, err := time.ParseInLocation("20060102", s[:8], time.Local)
+		if err != nil {
+			return time.Time{}, false
+		}
+		// 截止日期当天结束
+		return t.AddDate(0, 0, 1), true
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,434 @@
+package calendar
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	tsError "github.com/Premium-Platform/go-tushare/pkg/errors"
+)
+
+// dateLayout 日期格式
+const dateLayout = "20060102"
+
+// recheckInterval 确认数据源没有更晚的日期后，超过该时长再次查询时重新检查数据源是否已发布新的日历
+const recheckInterval = 24 * time.Hour
+
+// Day 交易日历中的一天
+type Day struct {
+	Date string // 日历日期 YYYYMMDD
+	Open bool   // 是否交易
+}
+
+// FetchFunc 获取交易所在日期区间（含）内的交易日历，未发布的日期不返回
+type FetchFunc func(exchange, startDate, endDate string) ([]Day, error)
+
+// Calendar 单个交易所的交易日历
+//
+// 交易日历按自然年从数据源获取并缓存在本地；查询的日期超出已缓存的范围时自动获取对应年份的数据。
+// 数据源只包含已发布的日历，超出已发布范围的查询返回ErrOutOfRange。已确认超出数据源范围的日期不再重复获取，
+// 确认超过一天或当前日期已超过缓存的最后一个日期时重新检查数据源，长期运行时可以获取新发布的日历。
+type Calendar struct {
+	mu       sync.Mutex
+	exchange string
+	fetch    FetchFunc
+	source   string    // 数据源说明，用于错误信息
+	first    string    // 已缓存的第一个日历日期
+	last     string    // 已缓存的最后一个日历日期
+	atFirst  bool      // first已是数据源的第一个日期
+	atLast   bool      // last已是数据源的最后一个日期
+	lastAt   time.Time // 确认atLast的时间
+	days     []string  // 已缓存的交易日（升序）
+	now      func() time.Time
+}
+
+// New 创建交易所的交易日历
+func New(exchange string, fetch FetchFunc) *Calendar {
+	return &Calendar{
+		exchange: exchange,
+		fetch:    fetch,
+		now:      time.Now,
+	}
+}
+
+// Exchange 获取交易所代码
+func (c *Calendar) Exchange() string {
+	return c.exchange
+}
+
+// Refresh 清空缓存，下次查询时重新获取
+func (c *Calendar) Refresh() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.first, c.last, c.days = "", "", nil
+	c.atFirst, c.atLast, c.lastAt = false, false, time.Time{}
+}
+
+// Horizon 获取已缓存的最后一个日历日期，未缓存时为空
+func (c *Calendar) Horizon() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.last
+}
+
+// IsTradingDay 判断是否为交易日
+func (c *Calendar) IsTradingDay(date string) (bool, error) {
+	if err := validateDate(date); err != nil {
+		return false, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.ensure(date, date); err != nil {
+		return false, err
+	}
+	if !c.covers(date) {
+		return false, c.outOfRange(date)
+	}
+	idx := sort.SearchStrings(c.days, date)
+	return idx < len(c.days) && c.days[idx] == date, nil
+}
+
+// NextTradingDay 获取指定日期之后（不含）的第一个交易日
+func (c *Calendar) NextTradingDay(date string) (string, error) {
+	if err := validateDate(date); err != nil {
+		return "", err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.next(date)
+}
+
+// PrevTradingDay 获取指定日期之前（不含）的最后一个交易日
+func (c *Calendar) PrevTradingDay(date string) (string, error) {
+	if err := validateDate(date); err != nil {
+		return "", err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.prev(date)
+}
+
+// Offset 获取指定日期偏移n个交易日后的日期
+//
+// n>0时向后偏移，Offset(date, 1)等同于NextTradingDay；n<0时向前偏移，Offset(date, -1)等同于PrevTradingDay；
+// n=0时指定日期为交易日则返回该日期，否则返回之后的第一个交易日
+func (c *Calendar) Offset(date string, n int) (string, error) {
+	if err := validateDate(date); err != nil {
+		return "", err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if n == 0 {
+		if err := c.ensure(date, date); err != nil {
+			return "", err
+		}
+		if !c.covers(date) {
+			return "", c.outOfRange(date)
+		}
+		idx := sort.SearchStrings(c.days, date)
+		if idx < len(c.days) && c.days[idx] == date {
+			return date, nil
+		}
+		return c.next(date)
+	}
+
+	var err error
+	for ; n > 0; n-- {
+		if date, err = c.next(date); err != nil {
+			return "", err
+		}
+	}
+	for ; n < 0; n++ {
+		if date, err = c.prev(date); err != nil {
+			return "", err
+		}
+	}
+	return date, nil
+}
+
+// TradingDaysBetween 获取日期区间（含）内的交易日（升序）
+//
+// 开始日期早于数据源的第一个日期或结束日期晚于已发布的最后一个日期时返回ErrOutOfRange
+func (c *Calendar) TradingDaysBetween(startDate, endDate string) ([]string, error) {
+	if err := validateDate(startDate); err != nil {
+		return nil, err
+	}
+	if err := validateDate(endDate); err != nil {
+		return nil, err
+	}
+	if startDate > endDate {
+		return nil, tsError.Wrapf(tsError.ErrInvalidParameter, "start date %s is after end date %s", startDate, endDate)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.ensure(startDate, endDate); err != nil {
+		return nil, err
+	}
+	if !c.covers(startDate) {
+		return nil, c.outOfRange(startDate)
+	}
+	if endDate > c.last && c.atLast {
+		return nil, c.outOfRange(endDate)
+	}
+
+	lo := sort.SearchStrings(c.days, startDate)
+	hi := sort.Search(len(c.days), func(i int) bool { return c.days[i] > endDate })
+	return append([]string(nil), c.days[lo:hi]...), nil
+}
+
+// Count 获取日期区间（含）内的交易日数
+func (c *Calendar) Count(startDate, endDate string) (int, error) {
+	days, err := c.TradingDaysBetween(startDate, endDate)
+	if err != nil {
+		return 0, err
+	}
+	return len(days), nil
+}
+
+// LastTradingDayOfWeek 获取日期所在自然周（周一至周日）的最后一个交易日，该周没有交易日时返回空字符串
+func (c *Calendar) LastTradingDayOfWeek(date string) (string, error) {
+	t, err := parseDate(date)
+	if err != nil {
+		return "", err
+	}
+	offset := (int(t.Weekday()) + 6) % 7
+	start := t.AddDate(0, 0, -offset)
+	return c.lastInPeriod(start, start.AddDate(0, 0, 6))
+}
+
+// LastTradingDayOfMonth 获取日期所在自然月的最后一个交易日，该月没有交易日时返回空字符串
+func (c *Calendar) LastTradingDayOfMonth(date string) (string, error) {
+	t, err := parseDate(date)
+	if err != nil {
+		return "", err
+	}
+	start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return c.lastInPeriod(start, start.AddDate(0, 1, -1))
+}
+
+// LastTradingDayOfQuarter 获取日期所在季度的最后一个交易日，该季度没有交易日时返回空字符串
+func (c *Calendar) LastTradingDayOfQuarter(date string) (string, error) {
+	t, err := parseDate(date)
+	if err != nil {
+		return "", err
+	}
+	month := time.Month((int(t.Month())-1)/3*3 + 1)
+	start := time.Date(t.Year(), month, 1, 0, 0, 0, 0, time.UTC)
+	return c.lastInPeriod(start, start.AddDate(0, 3, -1))
+}
+
+// lastInPeriod 获取区间内的最后一个交易日，区间需已全部发布
+func (c *Calendar) lastInPeriod(start, end time.Time) (string, error) {
+	startDate, endDate := start.Format(dateLayout), end.Format(dateLayout)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.ensure(startDate, endDate); err != nil {
+		return "", err
+	}
+	if !c.covers(startDate) {
+		return "", c.outOfRange(startDate)
+	}
+	if !c.covers(endDate) {
+		return "", c.outOfRange(endDate)
+	}
+
+	idx := sort.Search(len(c.days), func(i int) bool { return c.days[i] > endDate })
+	if idx == 0 || c.days[idx-1] < startDate {
+		return "", nil
+	}
+	return c.days[idx-1], nil
+}
+
+// next 获取之后的第一个交易日，需持有锁
+func (c *Calendar) next(date string) (string, error) {
+	if err := c.ensure(date, date); err != nil {
+		return "", err
+	}
+	if !c.covers(date) {
+		return "", c.outOfRange(date)
+	}
+
+	for {
+		idx := sort.Search(len(c.days), func(i int) bool { return c.days[i] > date })
+		if idx < len(c.days) {
+			return c.days[idx], nil
+		}
+
+		// 已缓存范围内没有更晚的交易日，获取下一年
+		last := c.last
+		t, _ := parseDate(last)
+		t = t.AddDate(0, 0, 1)
+		if c.exhausted() {
+			return "", c.outOfRange(t.Format(dateLayout))
+		}
+		if err := c.load(t.Format(dateLayout), yearEnd(t)); err != nil {
+			return "", err
+		}
+		if c.last == last {
+			return "", c.outOfRange(t.Format(dateLayout))
+		}
+	}
+}
+
+// prev 获取之前的最后一个交易日，需持有锁
+func (c *Calendar) prev(date string) (string, error) {
+	if err := c.ensure(date, date); err != nil {
+		return "", err
+	}
+	if !c.covers(date) {
+		return "", c.outOfRange(date)
+	}
+
+	for {
+		idx := sort.SearchStrings(c.days, date)
+		if idx > 0 {
+			return c.days[idx-1], nil
+		}
+
+		// 已缓存范围内没有更早的交易日，获取上一年
+		first := c.first
+		t, _ := parseDate(first)
+		t = t.AddDate(0, 0, -1)
+		if c.atFirst {
+			return "", c.outOfRange(t.Format(dateLayout))
+		}
+		if err := c.load(yearStart(t), t.Format(dateLayout)); err != nil {
+			return "", err
+		}
+		if c.first == first {
+			return "", c.outOfRange(t.Format(dateLayout))
+		}
+	}
+}
+
+// ensure 确保缓存覆盖日期区间，按自然年获取缺失的部分，需持有锁
+func (c *Calendar) ensure(startDate, endDate string) error {
+	start, _ := parseDate(startDate)
+	end, _ := parseDate(endDate)
+
+	if c.first == "" {
+		return c.load(yearStart(start), yearEnd(end))
+	}
+
+	if startDate < c.first && !c.atFirst {
+		t, _ := parseDate(c.first)
+		if err := c.load(yearStart(start), t.AddDate(0, 0, -1).Format(dateLayout)); err != nil {
+			return err
+		}
+	}
+	if endDate > c.last && !c.exhausted() {
+		t, _ := parseDate(c.last)
+		if err := c.load(t.AddDate(0, 0, 1).Format(dateLayout), yearEnd(end)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// load 从数据源获取日期区间内的交易日历并合并到缓存，返回的日期未覆盖区间两端时记录已到达数据源的范围，需持有锁
+func (c *Calendar) load(startDate, endDate string) error {
+	days, err := c.fetch(c.exchange, startDate, endDate)
+	if err != nil {
+		return err
+	}
+	prevLast := c.last
+
+	for _, day := range days {
+		if day.Date == "" {
+			continue
+		}
+		if c.first == "" || day.Date < c.first {
+			c.first = day.Date
+		}
+		if c.last == "" || day.Date > c.last {
+			c.last = day.Date
+		}
+		if day.Open {
+			c.days = append(c.days, day.Date)
+		}
+	}
+
+	if c.first != "" {
+		c.atFirst = c.atFirst || c.first > startDate
+		// 获取了已缓存范围之后的日期时重新记录是否已到达数据源的最后一个日期
+		if endDate > prevLast {
+			c.atLast, c.lastAt = c.last < endDate, c.now()
+		}
+	}
+
+	// 排序并去重
+	sort.Strings(c.days)
+	unique := c.days[:0]
+	for i, d := range c.days {
+		if i == 0 || d != c.days[i-1] {
+			unique = append(unique, d)
+		}
+	}
+	c.days = unique
+	return nil
+}
+
+// exhausted 是否已确认数据源没有比last更晚的日期，需持有锁
+//
+// 确认超过recheckInterval，或当前日期已超过last且当天尚未检查过时，需要重新检查数据源
+func (c *Calendar) exhausted() bool {
+	if !c.atLast {
+		return false
+	}
+	now := c.now()
+	if now.Sub(c.lastAt) >= recheckInterval {
+		return false
+	}
+	today := now.Format(dateLayout)
+	return today <= c.last || c.lastAt.Format(dateLayout) >= today
+}
+
+// covers 判断日期是否在已缓存的范围内，需持有锁
+func (c *Calendar) covers(date string) bool {
+	return c.first != "" && date >= c.first && date <= c.last
+}
+
+// outOfRange 构造超出日历范围的错误，需持有锁
+func (c *Calendar) outOfRange(date string) error {
+	if c.source != "" {
+		first, last, _ := SnapshotRange(c.exchange)
+		return tsError.Wrapf(tsError.ErrOutOfRange, "%s calendar from %s covers %s-%s, requested %s is beyond the snapshot horizon",
+			c.exchange, c.source, first, last, date)
+	}
+	return tsError.Wrapf(tsError.ErrOutOfRange, "%s calendar covers %s-%s, requested %s", c.exchange, c.first, c.last, date)
+}
+
+// validateDate 检查日期格式
+func validateDate(date string) error {
+	_, err := parseDate(date)
+	return err
+}
+
+// parseDate 解析YYYYMMDD格式的日期
+func parseDate(date string) (time.Time, error) {
+	t, err := time.Parse(dateLayout, date)
+	if err != nil {
+		return time.Time{}, tsError.Wrapf(tsError.ErrInvalidParameter, "invalid date %q", date)
+	}
+	return t, nil
+}
+
+// yearStart 获取所在年份的第一天
+func yearStart(t time.Time) string {
+	return time.Date(t.Year(), 1, 1, 0, 0, 0, 0, time.UTC).Format(dateLayout)
+}
+
+// yearEnd 获取所在年份的最后一天
+func yearEnd(t time.Time) string {
+	return time.Date(t.Year(), 12, 31, 0, 0, 0, 0, time.UTC).Format(dateLayout)
+}
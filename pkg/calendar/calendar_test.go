@@ -0,0 +1,147 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+
+	tsError "github.com/Premium-Platform/go-tushare/pkg/errors"
+)
+
+// countingFetch 只发布2023-2024年日历（周一至周五交易）的数据源，记录调用次数
+func countingFetch(calls *int) FetchFunc {
+	lastYear := 2024
+	return publishingFetch(calls, &lastYear)
+}
+
+// publishingFetch 发布2023年至lastYear年日历的数据源，记录调用次数
+func publishingFetch(calls, lastYear *int) FetchFunc {
+	return func(exchange, startDate, endDate string) ([]Day, error) {
+		*calls++
+		start, _ := parseDate(startDate)
+		end, _ := parseDate(endDate)
+		days := make([]Day, 0)
+		for t := start; !t.After(end); t = t.AddDate(0, 0, 1) {
+			if t.Year() < 2023 || t.Year() > *lastYear {
+				continue
+			}
+			days = append(days, Day{Date: t.Format(dateLayout), Open: t.Weekday() != time.Saturday && t.Weekday() != time.Sunday})
+		}
+		return days, nil
+	}
+}
+
+// TestOutOfRangeNotRefetched 超出数据源范围的查询返回ErrOutOfRange，不重复获取
+func TestOutOfRangeNotRefetched(t *testing.T) {
+	var calls int
+	cal := New("SSE", countingFetch(&calls))
+
+	if _, err := cal.IsTradingDay("20240105"); err != nil {
+		t.Fatal(err)
+	}
+	if day, err := cal.NextTradingDay("20241230"); err != nil || day != "20241231" {
+		t.Fatalf("NextTradingDay = %s, %v, want 20241231", day, err)
+	}
+
+	before := calls
+	for i := 0; i < 3; i++ {
+		if _, err := cal.IsTradingDay("20250102"); tsError.Cause(err) != tsError.ErrOutOfRange {
+			t.Errorf("IsTradingDay beyond horizon: err = %v, want ErrOutOfRange", err)
+		}
+		if _, err := cal.NextTradingDay("20241231"); tsError.Cause(err) != tsError.ErrOutOfRange {
+			t.Errorf("NextTradingDay beyond horizon: err = %v, want ErrOutOfRange", err)
+		}
+	}
+	if calls > before+1 {
+		t.Errorf("got %d fetches beyond horizon, want at most 1", calls-before)
+	}
+
+	// 早于数据源的日期同样不重复获取（首次查询获取2023年及2022年）
+	if day, err := cal.PrevTradingDay("20230103"); err != nil || day != "20230102" {
+		t.Fatalf("PrevTradingDay = %s, %v, want 20230102", day, err)
+	}
+	cal.PrevTradingDay("20230102") // 获取2022年，确认数据源没有更早的日期
+	before = calls
+	for i := 0; i < 3; i++ {
+		if _, err := cal.PrevTradingDay("20230102"); tsError.Cause(err) != tsError.ErrOutOfRange {
+			t.Errorf("PrevTradingDay before first date: err = %v, want ErrOutOfRange", err)
+		}
+	}
+	if calls != before {
+		t.Errorf("got %d fetches before first date, want none", calls-before)
+	}
+
+	// Refresh后重新获取
+	cal.Refresh()
+	before = calls
+	if _, err := cal.IsTradingDay("20240105"); err != nil || calls != before+1 {
+		t.Errorf("after Refresh: err = %v, fetches = %d, want 1", err, calls-before)
+	}
+}
+
+// TestTradingDaysBetweenPastHorizon 结束日期晚于数据源的最后一个日期时返回ErrOutOfRange，不截断结果
+func TestTradingDaysBetweenPastHorizon(t *testing.T) {
+	var calls int
+	cal := New("SSE", countingFetch(&calls))
+
+	days, err := cal.TradingDaysBetween("20241223", "20241231")
+	if err != nil || len(days) != 7 {
+		t.Fatalf("TradingDaysBetween = %v, %v, want 7 days", days, err)
+	}
+	if _, err := cal.TradingDaysBetween("20241223", "20250110"); tsError.Cause(err) != tsError.ErrOutOfRange {
+		t.Errorf("TradingDaysBetween past horizon: err = %v, want ErrOutOfRange", err)
+	}
+}
+
+func TestSnapshotExchanges(t *testing.T) {
+	for _, exchange := range []string{"SSE", "SZSE", "CFFEX", "SHFE", "CZCE", "DCE", "INE", "GFEX"} {
+		cal, err := NewSnapshot(exchange)
+		if err != nil {
+			t.Fatalf("NewSnapshot(%s): %v", exchange, err)
+		}
+		if ok, err := cal.IsTradingDay("20240102"); err != nil || !ok {
+			t.Errorf("%s IsTradingDay(20240102) = %v, %v, want true", exchange, ok, err)
+		}
+	}
+}
+
+// TestRecheckAfterHorizon 确认没有更晚的日期超过一天，或当前日期超过已缓存的最后一个日期后，重新检查数据源
+func TestRecheckAfterHorizon(t *testing.T) {
+	var calls int
+	lastYear := 2024
+	now := time.Date(2024, 12, 20, 10, 0, 0, 0, time.Local)
+	cal := New("SSE", publishingFetch(&calls, &lastYear))
+	cal.now = func() time.Time { return now }
+
+	if _, err := cal.IsTradingDay("20241220"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cal.IsTradingDay("20250102"); tsError.Cause(err) != tsError.ErrOutOfRange {
+		t.Fatalf("before publishing: err = %v, want ErrOutOfRange", err)
+	}
+
+	// 数据源发布了2025年日历，但一天内不重新检查
+	lastYear = 2025
+	before := calls
+	now = now.Add(time.Hour)
+	if _, err := cal.IsTradingDay("20250102"); tsError.Cause(err) != tsError.ErrOutOfRange || calls != before {
+		t.Errorf("within a day: err = %v, fetches = %d, want ErrOutOfRange without fetching", err, calls-before)
+	}
+
+	// 超过一天后重新检查
+	now = now.Add(recheckInterval)
+	if ok, err := cal.IsTradingDay("20250102"); err != nil || !ok {
+		t.Errorf("after a day: IsTradingDay = %v, %v, want true", ok, err)
+	}
+
+	// 当前日期超过已缓存的最后一个日期时当天重新检查一次
+	before = calls
+	now = time.Date(2026, 1, 1, 9, 0, 0, 0, time.Local)
+	lastYear = 2026
+	cal.lastAt = now.Add(-time.Hour)
+	if _, err := cal.NextTradingDay("20251231"); err != nil {
+		t.Errorf("after horizon passed: err = %v", err)
+	}
+	if calls != before+1 {
+		t.Errorf("after horizon passed: got %d fetches, want 1", calls-before)
+	}
+}
@@ -0,0 +1,145 @@
+package calendar
+
+import (
+	"bytes"
+	"embed"
+	"encoding/csv"
+	"io"
+	"sort"
+	"sync"
+
+	tsError "github.com/Premium-Platform/go-tushare/pkg/errors"
+)
+
+// SnapshotFile 内置交易日历快照在包内的路径
+const SnapshotFile = "snapshot/trade_cal.csv"
+
+//go:embed snapshot/trade_cal.csv
+var snapshotFS embed.FS
+
+var (
+	snapshotOnce sync.Once
+	snapshotData map[string][]Day
+	snapshotErr  error
+)
+
+// loadSnapshot 解析内置的交易日历快照
+func loadSnapshot() (map[string][]Day, error) {
+	snapshotOnce.Do(func() {
+		data, err := snapshotFS.ReadFile(SnapshotFile)
+		if err != nil {
+			snapshotErr = err
+			return
+		}
+		snapshotData, snapshotErr = ReadSnapshot(bytes.NewReader(data))
+	})
+	return snapshotData, snapshotErr
+}
+
+// ReadSnapshot 读取CSV格式的交易日历快照（exchange,cal_date,is_open），按交易所分组并按日期升序排列
+func ReadSnapshot(r io.Reader) (map[string][]Day, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, tsError.Wrapf(tsError.ErrInvalidParameter, "invalid calendar snapshot: %v", err)
+	}
+
+	result := make(map[string][]Day)
+	for i, record := range records {
+		if i == 0 && len(record) > 0 && record[0] == "exchange" {
+			continue
+		}
+		if len(record) != 3 {
+			return nil, tsError.Wrapf(tsError.ErrInvalidParameter, "invalid calendar snapshot line %d", i+1)
+		}
+		if err := validateDate(record[1]); err != nil {
+			return nil, err
+		}
+		result[record[0]] = append(result[record[0]], Day{Date: record[1], Open: record[2] == "1"})
+	}
+
+	for _, days := range result {
+		sort.Slice(days, func(i, j int) bool { return days[i].Date < days[j].Date })
+	}
+	return result, nil
+}
+
+// WriteSnapshot 将交易日历写为CSV格式的快照，交易所和日期均按升序排列
+func WriteSnapshot(w io.Writer, calendars map[string][]Day) error {
+	exchanges := make([]string, 0, len(calendars))
+	for exchange := range calendars {
+		exchanges = append(exchanges, exchange)
+	}
+	sort.Strings(exchanges)
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"exchange", "cal_date", "is_open"}); err != nil {
+		return err
+	}
+	for _, exchange := range exchanges {
+		days := append([]Day(nil), calendars[exchange]...)
+		sort.Slice(days, func(i, j int) bool { return days[i].Date < days[j].Date })
+		for _, day := range days {
+			open := "0"
+			if day.Open {
+				open = "1"
+			}
+			if err := cw.Write([]string{exchange, day.Date, open}); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// SnapshotExchanges 获取内置快照包含的交易所
+func SnapshotExchanges() []string {
+	data, err := loadSnapshot()
+	if err != nil {
+		return nil
+	}
+	exchanges := make([]string, 0, len(data))
+	for exchange := range data {
+		exchanges = append(exchanges, exchange)
+	}
+	sort.Strings(exchanges)
+	return exchanges
+}
+
+// SnapshotRange 获取内置快照中交易所日历的起止日期
+func SnapshotRange(exchange string) (first, last string, ok bool) {
+	data, err := loadSnapshot()
+	if err != nil || len(data[exchange]) == 0 {
+		return "", "", false
+	}
+	days := data[exchange]
+	return days[0].Date, days[len(days)-1].Date, true
+}
+
+// SnapshotFetch 以内置快照为数据源的FetchFunc，不需要网络和令牌
+func SnapshotFetch(exchange, startDate, endDate string) ([]Day, error) {
+	data, err := loadSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	days, ok := data[exchange]
+	if !ok {
+		return nil, tsError.Wrapf(tsError.ErrInvalidParameter, "exchange %s is not in the calendar snapshot", exchange)
+	}
+
+	lo := sort.Search(len(days), func(i int) bool { return days[i].Date >= startDate })
+	hi := sort.Search(len(days), func(i int) bool { return days[i].Date > endDate })
+	return append([]Day(nil), days[lo:hi]...), nil
+}
+
+// NewSnapshot 创建以内置快照为数据源的交易日历
+//
+// 查询超出快照范围时返回ErrOutOfRange，错误信息中包含快照的起止日期
+func NewSnapshot(exchange string) (*Calendar, error) {
+	if _, _, ok := SnapshotRange(exchange); !ok {
+		return nil, tsError.Wrapf(tsError.ErrInvalidParameter, "exchange %s is not in the calendar snapshot", exchange)
+	}
+	cal := New(exchange, SnapshotFetch)
+	cal.source = "embedded snapshot"
+	return cal, nil
+}
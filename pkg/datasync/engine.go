@@ -0,0 +1,219 @@
+package datasync
+
+import (
+	"math"
+
+	tsError "github.com/Premium-Platform/go-tushare/pkg/errors"
+	"github.com/Premium-Platform/go-tushare/pkg/logger"
+	"github.com/Premium-Platform/go-tushare/pkg/types"
+)
+
+// DefaultMaxRetries 行数与预期不符的键默认最多重新获取的次数
+const DefaultMaxRetries = 3
+
+// Dataset 需要同步的数据集
+type Dataset struct {
+	Name string // 数据集名称，作为存储目录名
+
+	// Keys 获取日期区间内应同步的键（交易日期或报告期，升序）
+	Keys func(startDate, endDate string) ([]string, error)
+	// Fetch 获取某个键的全部数据
+	Fetch func(key string) (*types.DataFrame, error)
+	// Expect 获取某个键的预期行数，ok为false时不校验；为nil时不校验
+	Expect func(key string) (expected int, ok bool, err error)
+	// Resync 已同步的键是否需要重新获取（如仍可能有新披露数据的近期报告期）；为nil时不重新获取
+	Resync func(key string) bool
+	// Settled 键的数据是否已不再变化，行数不符时只有不再变化的键计入重新获取次数；为nil时全部计入
+	Settled func(key string) bool
+
+	// Tolerance 行数校验允许的相对误差，如0.01表示与预期相差1%以内视为相符
+	Tolerance float64
+	// MaxRetries 行数与预期不符时最多重新获取的次数，超过后按已获取的数据视为已同步；0使用DefaultMaxRetries
+	MaxRetries int
+}
+
+// Mismatch 行数与预期不符的键
+type Mismatch struct {
+	Key      string
+	Rows     int
+	Expected int
+	Accepted bool // 已达重新获取次数上限，不再重新获取
+}
+
+// Result 单个数据集的同步结果
+type Result struct {
+	Dataset    string
+	Last       string           // 同步后已同步的最大键
+	Fetched    []string         // 本次获取的键
+	Skipped    int              // 已同步而跳过的键数
+	Rows       int              // 本次获取的总行数
+	Mismatches []Mismatch       // 行数与预期不符的键
+	Errors     map[string]error // 获取或保存失败的键及对应错误
+}
+
+// Engine 增量同步引擎
+//
+// 按数据集的键（交易日期或报告期）逐个获取缺失的数据并保存到本地存储，每个键完成后保存检查点，
+// 中断后再次运行从检查点继续；行数与预期不符的键照常保存，并在下次运行时重新获取，
+// 数据不再变化后重新获取MaxRetries次仍不符（如预期行数本身有偏差）时不再重新获取。
+type Engine struct {
+	store  Store
+	logger *logger.Logger
+}
+
+// NewEngine 创建同步引擎
+func NewEngine(store Store) *Engine {
+	return &Engine{
+		store:  store,
+		logger: logger.NewLogger(nil, logger.INFO),
+	}
+}
+
+// SetLogger 设置日志记录器
+func (e *Engine) SetLogger(l *logger.Logger) {
+	e.logger = l
+}
+
+// Store 获取本地存储
+func (e *Engine) Store() Store {
+	return e.store
+}
+
+// Status 获取数据集的同步进度
+func (e *Engine) Status(dataset string) (*Checkpoint, error) {
+	return e.store.LoadCheckpoint(dataset)
+}
+
+// Run 依次同步多个数据集，单个键失败不中断同步，错误记录在结果中；读写检查点失败时返回错误
+func (e *Engine) Run(startDate, endDate string, datasets ...Dataset) ([]*Result, error) {
+	results := make([]*Result, 0, len(datasets))
+	for _, ds := range datasets {
+		result, err := e.Sync(ds, startDate, endDate)
+		if err != nil {
+			return results, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// Sync 同步单个数据集在日期区间内缺失的数据
+func (e *Engine) Sync(ds Dataset, startDate, endDate string) (*Result, error) {
+	if ds.Name == "" || ds.Keys == nil || ds.Fetch == nil {
+		return nil, tsError.Wrap(tsError.ErrInvalidParameter, "dataset name, keys and fetch are required")
+	}
+
+	cp, err := e.store.LoadCheckpoint(ds.Name)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := ds.Keys(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{Dataset: ds.Name, Errors: make(map[string]error)}
+	pending := make([]string, 0)
+	for _, key := range keys {
+		if cp.Synced(key) && (ds.Resync == nil || !ds.Resync(key)) {
+			result.Skipped++
+			continue
+		}
+		pending = append(pending, key)
+	}
+	e.logger.Info("开始同步%s: 待同步%d个, 已同步%d个", ds.Name, len(pending), result.Skipped)
+
+	for _, key := range pending {
+		df, err := ds.Fetch(key)
+		if err != nil {
+			e.logger.Warn("获取%s失败, key=%s: %v", ds.Name, key, err)
+			result.Errors[key] = err
+			continue
+		}
+		rows := 0
+		if df != nil {
+			rows = len(df.Rows)
+		}
+
+		expected, mismatch, err := e.verify(ds, key, rows)
+		if err != nil {
+			e.logger.Warn("获取%s预期行数失败, key=%s: %v", ds.Name, key, err)
+			result.Errors[key] = err
+			continue
+		}
+		if err := e.store.Write(ds.Name, key, df); err != nil {
+			e.logger.Warn("保存%s失败, key=%s: %v", ds.Name, key, err)
+			result.Errors[key] = err
+			continue
+		}
+
+		// 数据不再变化后才累计行数不符的获取次数，达到上限时按已获取的数据视为已同步
+		accepted := false
+		if mismatch && (ds.Settled == nil || ds.Settled(key)) {
+			retries := cp.retried(key)
+			accepted = retries >= ds.maxRetries()
+		}
+		cp.record(key, rows, expected, mismatch && !accepted)
+		if err := e.store.SaveCheckpoint(cp); err != nil {
+			return nil, err
+		}
+
+		result.Fetched = append(result.Fetched, key)
+		result.Rows += rows
+		if mismatch {
+			if accepted {
+				e.logger.Warn("%s行数与预期不符且已达重新获取次数上限, 不再重新获取, key=%s: %d行, 预期%d行", ds.Name, key, rows, expected)
+			} else {
+				e.logger.Warn("%s行数与预期不符, key=%s: %d行, 预期%d行", ds.Name, key, rows, expected)
+			}
+			result.Mismatches = append(result.Mismatches, Mismatch{Key: key, Rows: rows, Expected: expected, Accepted: accepted})
+		}
+		e.logger.Debug("已同步%s, key=%s, %d行", ds.Name, key, rows)
+	}
+
+	result.Last = cp.Last
+	e.logger.Info("同步%s完成: 获取%d个, %d行, 行数不符%d个, 失败%d个",
+		ds.Name, len(result.Fetched), result.Rows, len(result.Mismatches), len(result.Errors))
+	return result, nil
+}
+
+// maxRetries 行数不符时最多重新获取的次数
+func (ds Dataset) maxRetries() int {
+	if ds.MaxRetries <= 0 {
+		return DefaultMaxRetries
+	}
+	return ds.MaxRetries
+}
+
+// verify 校验行数，返回预期行数和是否不符
+func (e *Engine) verify(ds Dataset, key string, rows int) (int, bool, error) {
+	if ds.Expect == nil {
+		return 0, false, nil
+	}
+	expected, ok, err := ds.Expect(key)
+	if err != nil || !ok {
+		return 0, false, err
+	}
+	diff := math.Abs(float64(rows - expected))
+	return expected, diff > float64(expected)*ds.Tolerance, nil
+}
+
+// ReadRange 读取数据集在键区间（含）内已同步的数据并按键顺序合并
+func (e *Engine) ReadRange(dataset, startKey, endKey string) (*types.DataFrame, error) {
+	cp, err := e.store.LoadCheckpoint(dataset)
+	if err != nil {
+		return nil, err
+	}
+	frames := make([]*types.DataFrame, 0)
+	for _, key := range cp.Keys() {
+		if key < startKey || key > endKey {
+			continue
+		}
+		df, err := e.store.Read(dataset, key)
+		if err != nil {
+			return nil, err
+		}
+		frames = append(frames, df)
+	}
+	return types.Concat(frames...), nil
+}
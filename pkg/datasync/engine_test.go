@@ -0,0 +1,169 @@
+package datasync
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Premium-Platform/go-tushare/pkg/types"
+)
+
+// memStore 内存数据存储，检查点保存时复制，模拟重新加载
+type memStore struct {
+	frames      map[string]*types.DataFrame
+	checkpoints map[string]Checkpoint
+}
+
+func newMemStore() *memStore {
+	return &memStore{frames: make(map[string]*types.DataFrame), checkpoints: make(map[string]Checkpoint)}
+}
+
+func (s *memStore) Write(dataset, key string, df *types.DataFrame) error {
+	s.frames[dataset+"/"+key] = df
+	return nil
+}
+
+func (s *memStore) Read(dataset, key string) (*types.DataFrame, error) {
+	return s.frames[dataset+"/"+key], nil
+}
+
+func (s *memStore) LoadCheckpoint(dataset string) (*Checkpoint, error) {
+	saved, ok := s.checkpoints[dataset]
+	cp := NewCheckpoint(dataset)
+	if !ok {
+		return cp, nil
+	}
+	cp.Last = saved.Last
+	for k, v := range saved.Counts {
+		cp.Counts[k] = v
+	}
+	for k, v := range saved.Mismatches {
+		cp.Mismatches[k] = v
+	}
+	for k, v := range saved.Retries {
+		cp.Retries[k] = v
+	}
+	return cp, nil
+}
+
+func (s *memStore) SaveCheckpoint(cp *Checkpoint) error {
+	s.checkpoints[cp.Dataset] = *cp
+	return nil
+}
+
+// rowsFrame 指定行数的数据
+func rowsFrame(n int) *types.DataFrame {
+	rows := make([]map[string]interface{}, n)
+	for i := range rows {
+		rows[i] = map[string]interface{}{"i": i}
+	}
+	return types.NewDataFrame([]string{"i"}, rows)
+}
+
+func TestSyncResumeAndRetryLimit(t *testing.T) {
+	keys := []string{"20240102", "20240103", "20240104", "20240105"}
+	fetched := make([]string, 0)
+	failing := "20240104"
+
+	ds := Dataset{
+		Name: "daily",
+		Keys: func(startDate, endDate string) ([]string, error) { return keys, nil },
+		Fetch: func(key string) (*types.DataFrame, error) {
+			fetched = append(fetched, key)
+			if key == failing {
+				return nil, errors.New("network error")
+			}
+			return rowsFrame(10), nil
+		},
+		// 20240103的预期行数始终偏大（如主数据中的上市股票数有偏差）
+		Expect: func(key string) (int, bool, error) {
+			if key == "20240103" {
+				return 20, true, nil
+			}
+			return 10, true, nil
+		},
+		MaxRetries: 2,
+	}
+
+	store := newMemStore()
+	engine := NewEngine(store)
+	run := func() *Result {
+		t.Helper()
+		fetched = fetched[:0]
+		result, err := engine.Sync(ds, "20240101", "20240131")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result
+	}
+
+	// 第一次运行：20240104获取失败，其余保存；20240103行数不符
+	r := run()
+	if len(r.Fetched) != 3 || r.Errors[failing] == nil || len(r.Mismatches) != 1 {
+		t.Fatalf("run 1: fetched %v, errors %v, mismatches %v", r.Fetched, r.Errors, r.Mismatches)
+	}
+	if r.Last != "20240105" {
+		t.Errorf("run 1: last = %s, want 20240105", r.Last)
+	}
+
+	// 第二次运行：只重新获取失败和行数不符的键
+	failing = ""
+	r = run()
+	if want := []string{"20240103", "20240104"}; !equalStrings(fetched, want) {
+		t.Errorf("run 2: fetched %v, want %v", fetched, want)
+	}
+	if r.Skipped != 2 || len(r.Errors) != 0 || len(r.Mismatches) != 1 || r.Mismatches[0].Accepted {
+		t.Errorf("run 2: skipped %d, errors %v, mismatches %v", r.Skipped, r.Errors, r.Mismatches)
+	}
+
+	// 第三次运行：重新获取2次后仍不符，视为已同步
+	r = run()
+	if want := []string{"20240103"}; !equalStrings(fetched, want) {
+		t.Errorf("run 3: fetched %v, want %v", fetched, want)
+	}
+	if len(r.Mismatches) != 1 || !r.Mismatches[0].Accepted {
+		t.Errorf("run 3: mismatches %v, want accepted", r.Mismatches)
+	}
+
+	// 第四次运行：全部已同步
+	r = run()
+	if len(fetched) != 0 || r.Skipped != len(keys) {
+		t.Errorf("run 4: fetched %v, skipped %d", fetched, r.Skipped)
+	}
+	if df, _ := engine.ReadRange("daily", "20240101", "20240131"); df == nil || len(df.Rows) != 40 {
+		t.Errorf("ReadRange rows = %v, want 40", df)
+	}
+}
+
+// TestSyncUnsettledMismatch 数据尚未不再变化的键行数不符时不计入重新获取次数
+func TestSyncUnsettledMismatch(t *testing.T) {
+	ds := Dataset{
+		Name:    "daily",
+		Keys:    func(startDate, endDate string) ([]string, error) { return []string{"20240102"}, nil },
+		Fetch:   func(key string) (*types.DataFrame, error) { return rowsFrame(5), nil },
+		Expect:  func(key string) (int, bool, error) { return 10, true, nil },
+		Settled: func(key string) bool { return false },
+	}
+
+	engine := NewEngine(newMemStore())
+	for i := 0; i < DefaultMaxRetries+2; i++ {
+		r, err := engine.Sync(ds, "20240101", "20240131")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(r.Fetched) != 1 || len(r.Mismatches) != 1 || r.Mismatches[0].Accepted {
+			t.Fatalf("run %d: fetched %v, mismatches %v", i+1, r.Fetched, r.Mismatches)
+		}
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,228 @@
+package datasync
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	tsError "github.com/Premium-Platform/go-tushare/pkg/errors"
+	"github.com/Premium-Platform/go-tushare/pkg/types"
+)
+
+// checkpointFile 检查点文件名
+const checkpointFile = "_checkpoint.json"
+
+// Checkpoint 数据集的同步进度
+type Checkpoint struct {
+	Dataset    string         `json:"dataset"`
+	Last       string         `json:"last"`       // 已同步的最大键（交易日期或报告期）
+	Counts     map[string]int `json:"counts"`     // 已同步的键 -> 行数
+	Mismatches map[string]int `json:"mismatches"` // 行数与预期不符的键 -> 预期行数，下次同步时重新获取
+	Retries    map[string]int `json:"retries"`    // 行数与预期不符的键 -> 已获取的次数
+	UpdatedAt  time.Time      `json:"updated_at"`
+}
+
+// NewCheckpoint 创建空的检查点
+func NewCheckpoint(dataset string) *Checkpoint {
+	return &Checkpoint{
+		Dataset:    dataset,
+		Counts:     make(map[string]int),
+		Mismatches: make(map[string]int),
+		Retries:    make(map[string]int),
+	}
+}
+
+// Synced 键是否已同步且行数符合预期
+func (cp *Checkpoint) Synced(key string) bool {
+	_, ok := cp.Counts[key]
+	_, mismatch := cp.Mismatches[key]
+	return ok && !mismatch
+}
+
+// Keys 获取已同步的键（升序）
+func (cp *Checkpoint) Keys() []string {
+	keys := make([]string, 0, len(cp.Counts))
+	for key := range cp.Counts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// retried 记录一次行数不符的获取，返回该键行数不符的获取次数（首次获取之后为重新获取）
+func (cp *Checkpoint) retried(key string) int {
+	cp.Retries[key]++
+	return cp.Retries[key] - 1
+}
+
+// record 记录键的同步结果
+func (cp *Checkpoint) record(key string, rows, expected int, mismatch bool) {
+	cp.Counts[key] = rows
+	if mismatch {
+		cp.Mismatches[key] = expected
+	} else {
+		delete(cp.Mismatches, key)
+		delete(cp.Retries, key)
+	}
+	if key > cp.Last {
+		cp.Last = key
+	}
+	cp.UpdatedAt = time.Now()
+}
+
+// Store 本地数据存储
+type Store interface {
+	// Write 保存数据集某个键（交易日期或报告期）的数据，覆盖已有数据
+	Write(dataset, key string, df *types.DataFrame) error
+	// Read 读取数据集某个键的数据，不存在时返回nil
+	Read(dataset, key string) (*types.DataFrame, error)
+	// LoadCheckpoint 读取检查点，不存在时返回空的检查点
+	LoadCheckpoint(dataset string) (*Checkpoint, error)
+	// SaveCheckpoint 保存检查点
+	SaveCheckpoint(cp *Checkpoint) error
+}
+
+// FileStore 文件系统数据存储
+//
+// 每个数据集一个目录，每个键保存为一个JSON文件：目录/数据集/键.json，检查点保存在目录/数据集/_checkpoint.json；
+// 写入时先写临时文件再重命名，中断时不会留下不完整的文件
+type FileStore struct {
+	dir string
+}
+
+// frameFile 数据文件内容
+type frameFile struct {
+	Columns []string                 `json:"columns"`
+	Rows    []map[string]interface{} `json:"rows"`
+}
+
+// NewFileStore 创建文件系统数据存储，目录不存在时自动创建
+func NewFileStore(dir string) (*FileStore, error) {
+	if dir == "" {
+		return nil, tsError.Wrap(tsError.ErrInvalidParameter, "store dir is required")
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, tsError.Wrapf(err, "failed to create store dir %s", dir)
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+// Dir 获取存储目录
+func (s *FileStore) Dir() string {
+	return s.dir
+}
+
+// path 获取数据集中文件的路径
+func (s *FileStore) path(dataset, name string) (string, error) {
+	for _, part := range []string{dataset, name} {
+		if part == "" || strings.ContainsAny(part, `/\`) || strings.Contains(part, "..") {
+			return "", tsError.Wrapf(tsError.ErrInvalidParameter, "invalid store path %s/%s", dataset, name)
+		}
+	}
+	return filepath.Join(s.dir, dataset, name), nil
+}
+
+// Write 保存数据
+func (s *FileStore) Write(dataset, key string, df *types.DataFrame) error {
+	path, err := s.path(dataset, key+".json")
+	if err != nil {
+		return err
+	}
+	data := frameFile{Columns: []string{}, Rows: []map[string]interface{}{}}
+	if df != nil {
+		data.Columns, data.Rows = df.Columns, df.Rows
+	}
+	return writeJSON(path, data)
+}
+
+// Read 读取数据
+func (s *FileStore) Read(dataset, key string) (*types.DataFrame, error) {
+	path, err := s.path(dataset, key+".json")
+	if err != nil {
+		return nil, err
+	}
+	var data frameFile
+	ok, err := readJSON(path, &data)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return types.NewDataFrame(data.Columns, data.Rows), nil
+}
+
+// LoadCheckpoint 读取检查点
+func (s *FileStore) LoadCheckpoint(dataset string) (*Checkpoint, error) {
+	path, err := s.path(dataset, checkpointFile)
+	if err != nil {
+		return nil, err
+	}
+	cp := NewCheckpoint(dataset)
+	if _, err := readJSON(path, cp); err != nil {
+		return nil, err
+	}
+	if cp.Counts == nil {
+		cp.Counts = make(map[string]int)
+	}
+	if cp.Mismatches == nil {
+		cp.Mismatches = make(map[string]int)
+	}
+	if cp.Retries == nil {
+		cp.Retries = make(map[string]int)
+	}
+	return cp, nil
+}
+
+// SaveCheckpoint 保存检查点
+func (s *FileStore) SaveCheckpoint(cp *Checkpoint) error {
+	path, err := s.path(cp.Dataset, checkpointFile)
+	if err != nil {
+		return err
+	}
+	return writeJSON(path, cp)
+}
+
+// writeJSON 先写临时文件再重命名
+func writeJSON(path string, v interface{}) error {
+	content, err := json.Marshal(v)
+	if err != nil {
+		return tsError.Wrapf(err, "failed to encode %s", path)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return tsError.Wrapf(err, "failed to create dir for %s", path)
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return tsError.Wrapf(err, "failed to write %s", path)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return tsError.Wrapf(err, "failed to write %s", path)
+	}
+	if err := tmp.Close(); err != nil {
+		return tsError.Wrapf(err, "failed to write %s", path)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return tsError.Wrapf(err, "failed to write %s", path)
+	}
+	return nil
+}
+
+// readJSON 读取JSON文件，文件不存在时返回false
+func readJSON(path string, v interface{}) (bool, error) {
+	content, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, tsError.Wrapf(err, "failed to read %s", path)
+	}
+	if err := json.Unmarshal(content, v); err != nil {
+		return false, tsError.Wrapf(err, "failed to decode %s", path)
+	}
+	return true, nil
+}
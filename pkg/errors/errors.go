@@ -19,6 +19,9 @@ var (
 	// ErrInvalidParameter 表示参数无效
 	ErrInvalidParameter = errors.New("invalid parameter")
 
+	// ErrOutOfRange 表示日期超出交易日历的范围
+	ErrOutOfRange = errors.New("date out of calendar range")
+
 	// ErrServerError 表示服务器内部错误
 	ErrServerError = errors.New("server internal error")
 
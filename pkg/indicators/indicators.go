@@ -0,0 +1,431 @@
+package indicators
+
+import (
+	"fmt"
+	"math"
+
+	tsError "github.com/Premium-Platform/go-tushare/pkg/errors"
+	"github.com/Premium-Platform/go-tushare/pkg/types"
+)
+
+// 指标计算使用的行情列
+const (
+	colHigh  = "high"
+	colLow   = "low"
+	colClose = "close"
+	colVol   = "vol"
+	colCode  = "ts_code"
+)
+
+// column 获取数值列，列不存在时返回错误
+func column(df *types.DataFrame, name string) ([]float64, error) {
+	if !df.HasColumn(name) {
+		return nil, tsError.Wrapf(tsError.ErrInvalidParameter, "missing column %s", name)
+	}
+	return df.Float64Column(name), nil
+}
+
+// byCode 多证券长表（如BarMulti的结果）按ts_code分组分别计算，指标状态不跨越证券
+//
+// 分组与原DataFrame共享行，计算结果直接写入原始行；只有一个证券时返回false，由调用方直接计算
+func byCode(df *types.DataFrame, fn func(g *types.DataFrame) error) (bool, error) {
+	if !df.HasColumn(colCode) {
+		return false, nil
+	}
+
+	order := make([]string, 0)
+	groups := make(map[string][]map[string]interface{})
+	for _, row := range df.Rows {
+		code, _ := row[colCode].(string)
+		if _, ok := groups[code]; !ok {
+			order = append(order, code)
+		}
+		groups[code] = append(groups[code], row)
+	}
+	if len(order) <= 1 {
+		return false, nil
+	}
+
+	for _, code := range order {
+		g := types.NewDataFrame(append([]string(nil), df.Columns...), groups[code])
+		if err := fn(g); err != nil {
+			return true, err
+		}
+		for _, col := range g.Columns {
+			if !df.HasColumn(col) {
+				df.Columns = append(df.Columns, col)
+			}
+		}
+	}
+	return true, nil
+}
+
+// hlc 获取最高价、最低价、收盘价
+func hlc(df *types.DataFrame) (high, low, close []float64, err error) {
+	if high, err = column(df, colHigh); err != nil {
+		return
+	}
+	if low, err = column(df, colLow); err != nil {
+		return
+	}
+	close, err = column(df, colClose)
+	return
+}
+
+// AddEMA 添加指数移动平均列 ema_{n}
+func AddEMA(df *types.DataFrame, n int) error {
+	if grouped, err := byCode(df, func(g *types.DataFrame) error { return AddEMA(g, n) }); grouped {
+		return err
+	}
+	close, err := column(df, colClose)
+	if err != nil {
+		return err
+	}
+	df.SetColumn(fmt.Sprintf("ema_%d", n), types.FloatsToColumn(EMA(close, n)))
+	return nil
+}
+
+// AddSMA 添加简单移动平均列 sma_{n}
+func AddSMA(df *types.DataFrame, n int) error {
+	if grouped, err := byCode(df, func(g *types.DataFrame) error { return AddSMA(g, n) }); grouped {
+		return err
+	}
+	close, err := column(df, colClose)
+	if err != nil {
+		return err
+	}
+	df.SetColumn(fmt.Sprintf("sma_%d", n), types.FloatsToColumn(MA(close, n)))
+	return nil
+}
+
+// AddWMA 添加加权移动平均列 wma_{n}
+func AddWMA(df *types.DataFrame, n int) error {
+	if grouped, err := byCode(df, func(g *types.DataFrame) error { return AddWMA(g, n) }); grouped {
+		return err
+	}
+	close, err := column(df, colClose)
+	if err != nil {
+		return err
+	}
+	df.SetColumn(fmt.Sprintf("wma_%d", n), types.FloatsToColumn(WMA(close, n)))
+	return nil
+}
+
+// AddMACD 添加MACD指标列 macd_dif, macd_dea, macd
+//
+// DIF = EMA(C,short) - EMA(C,long)；DEA = EMA(DIF,mid)；MACD = (DIF-DEA)×2
+func AddMACD(df *types.DataFrame, short, long, mid int) error {
+	if grouped, err := byCode(df, func(g *types.DataFrame) error { return AddMACD(g, short, long, mid) }); grouped {
+		return err
+	}
+	close, err := column(df, colClose)
+	if err != nil {
+		return err
+	}
+
+	emaShort, emaLong := EMA(close, short), EMA(close, long)
+	dif := make([]float64, len(close))
+	for i := range close {
+		dif[i] = emaShort[i] - emaLong[i]
+	}
+	dea := EMA(dif, mid)
+	macd := make([]float64, len(close))
+	for i := range close {
+		macd[i] = (dif[i] - dea[i]) * 2
+	}
+
+	df.SetColumn("macd_dif", types.FloatsToColumn(dif))
+	df.SetColumn("macd_dea", types.FloatsToColumn(dea))
+	df.SetColumn("macd", types.FloatsToColumn(macd))
+	return nil
+}
+
+// AddKDJ 添加KDJ指标列 kdj_k, kdj_d, kdj_j
+//
+// RSV = (C-LLV(L,n))/(HHV(H,n)-LLV(L,n))×100；K = SMA(RSV,m1,1)；D = SMA(K,m2,1)；J = 3K-2D，
+// K、D的初始值（第一个有效RSV之前的K'、D'）为50
+func AddKDJ(df *types.DataFrame, n, m1, m2 int) error {
+	if grouped, err := byCode(df, func(g *types.DataFrame) error { return AddKDJ(g, n, m1, m2) }); grouped {
+		return err
+	}
+	high, low, close, err := hlc(df)
+	if err != nil {
+		return err
+	}
+
+	hhv, llv := HHV(high, n), LLV(low, n)
+	rsv := nanSlice(len(close))
+	for i := range close {
+		if rng := hhv[i] - llv[i]; rng != 0 {
+			rsv[i] = (close[i] - llv[i]) / rng * 100
+		}
+	}
+	k := smooth(rsv, 1/float64(m1), 50)
+	d := smooth(k, 1/float64(m2), 50)
+	j := make([]float64, len(close))
+	for i := range close {
+		j[i] = 3*k[i] - 2*d[i]
+	}
+
+	df.SetColumn("kdj_k", types.FloatsToColumn(k))
+	df.SetColumn("kdj_d", types.FloatsToColumn(d))
+	df.SetColumn("kdj_j", types.FloatsToColumn(j))
+	return nil
+}
+
+// AddRSI 添加相对强弱指标列 rsi_{n}
+//
+// RSI = SMA(MAX(C-LC,0),n,1)/SMA(ABS(C-LC),n,1)×100
+func AddRSI(df *types.DataFrame, n int) error {
+	if grouped, err := byCode(df, func(g *types.DataFrame) error { return AddRSI(g, n) }); grouped {
+		return err
+	}
+	close, err := column(df, colClose)
+	if err != nil {
+		return err
+	}
+
+	prev := REF(close, 1)
+	up := make([]float64, len(close))
+	abs := make([]float64, len(close))
+	for i := range close {
+		diff := close[i] - prev[i]
+		up[i] = math.Max(diff, 0)
+		abs[i] = math.Abs(diff)
+		if math.IsNaN(diff) {
+			up[i] = math.NaN()
+		}
+	}
+	smaUp, smaAbs := SMA(up, n, 1), SMA(abs, n, 1)
+	rsi := nanSlice(len(close))
+	for i := range close {
+		if smaAbs[i] != 0 {
+			rsi[i] = smaUp[i] / smaAbs[i] * 100
+		}
+	}
+
+	df.SetColumn(fmt.Sprintf("rsi_%d", n), types.FloatsToColumn(rsi))
+	return nil
+}
+
+// AddBOLL 添加布林线指标列 boll_upper, boll_mid, boll_lower
+//
+// MID = MA(C,n)；UPPER = MID + k×STD(C,n)；LOWER = MID - k×STD(C,n)，标准差为总体标准差
+func AddBOLL(df *types.DataFrame, n int, k float64) error {
+	if grouped, err := byCode(df, func(g *types.DataFrame) error { return AddBOLL(g, n, k) }); grouped {
+		return err
+	}
+	close, err := column(df, colClose)
+	if err != nil {
+		return err
+	}
+
+	mid, std := MA(close, n), STD(close, n)
+	upper := make([]float64, len(close))
+	lower := make([]float64, len(close))
+	for i := range close {
+		upper[i] = mid[i] + k*std[i]
+		lower[i] = mid[i] - k*std[i]
+	}
+
+	df.SetColumn("boll_upper", types.FloatsToColumn(upper))
+	df.SetColumn("boll_mid", types.FloatsToColumn(mid))
+	df.SetColumn("boll_lower", types.FloatsToColumn(lower))
+	return nil
+}
+
+// trueRange 真实波幅 MAX(MAX(H-L, ABS(H-LC)), ABS(L-LC))，首行没有前收盘价，结果为NaN
+func trueRange(high, low, close []float64) []float64 {
+	prev := REF(close, 1)
+	tr := nanSlice(len(close))
+	for i := range close {
+		if !math.IsNaN(prev[i]) {
+			tr[i] = math.Max(high[i]-low[i], math.Max(math.Abs(high[i]-prev[i]), math.Abs(low[i]-prev[i])))
+		}
+	}
+	return tr
+}
+
+// AddATR 添加平均真实波幅列 atr_{n}，ATR = MA(TR,n)
+func AddATR(df *types.DataFrame, n int) error {
+	if grouped, err := byCode(df, func(g *types.DataFrame) error { return AddATR(g, n) }); grouped {
+		return err
+	}
+	high, low, close, err := hlc(df)
+	if err != nil {
+		return err
+	}
+	df.SetColumn(fmt.Sprintf("atr_%d", n), types.FloatsToColumn(MA(trueRange(high, low, close), n)))
+	return nil
+}
+
+// AddOBV 添加能量潮指标列 obv
+//
+// 收盘价上涨累加成交量，下跌累减成交量，首行为0
+func AddOBV(df *types.DataFrame) error {
+	if grouped, err := byCode(df, func(g *types.DataFrame) error { return AddOBV(g) }); grouped {
+		return err
+	}
+	close, err := column(df, colClose)
+	if err != nil {
+		return err
+	}
+	vol, err := column(df, colVol)
+	if err != nil {
+		return err
+	}
+
+	prev := REF(close, 1)
+	signed := make([]float64, len(close))
+	for i := range close {
+		switch {
+		case close[i] > prev[i]:
+			signed[i] = vol[i]
+		case close[i] < prev[i]:
+			signed[i] = -vol[i]
+		}
+	}
+
+	df.SetColumn("obv", types.FloatsToColumn(SUM(signed, 0)))
+	return nil
+}
+
+// AddCCI 添加顺势指标列 cci_{n}
+//
+// TP = (H+L+C)/3；CCI = (TP-MA(TP,n))/(0.015×AVEDEV(TP,n))
+func AddCCI(df *types.DataFrame, n int) error {
+	if grouped, err := byCode(df, func(g *types.DataFrame) error { return AddCCI(g, n) }); grouped {
+		return err
+	}
+	high, low, close, err := hlc(df)
+	if err != nil {
+		return err
+	}
+
+	tp := make([]float64, len(close))
+	for i := range close {
+		tp[i] = (high[i] + low[i] + close[i]) / 3
+	}
+	ma, dev := MA(tp, n), AVEDEV(tp, n)
+	cci := nanSlice(len(close))
+	for i := range close {
+		if dev[i] != 0 {
+			cci[i] = (tp[i] - ma[i]) / (0.015 * dev[i])
+		}
+	}
+
+	df.SetColumn(fmt.Sprintf("cci_%d", n), types.FloatsToColumn(cci))
+	return nil
+}
+
+// AddWR 添加威廉指标列 wr_{n}，WR = (HHV(H,n)-C)/(HHV(H,n)-LLV(L,n))×100
+func AddWR(df *types.DataFrame, n int) error {
+	if grouped, err := byCode(df, func(g *types.DataFrame) error { return AddWR(g, n) }); grouped {
+		return err
+	}
+	high, low, close, err := hlc(df)
+	if err != nil {
+		return err
+	}
+
+	hhv, llv := HHV(high, n), LLV(low, n)
+	wr := nanSlice(len(close))
+	for i := range close {
+		if rng := hhv[i] - llv[i]; rng != 0 {
+			wr[i] = (hhv[i] - close[i]) / rng * 100
+		}
+	}
+
+	df.SetColumn(fmt.Sprintf("wr_%d", n), types.FloatsToColumn(wr))
+	return nil
+}
+
+// AddDMI 添加趋向指标列 dmi_pdi, dmi_mdi, dmi_adx, dmi_adxr
+//
+// TR = SUM(真实波幅,m1)；PDI = SUM(+DM,m1)×100/TR；MDI = SUM(-DM,m1)×100/TR；
+// ADX = MA(ABS(MDI-PDI)/(MDI+PDI)×100,m2)；ADXR = (ADX+REF(ADX,m2))/2
+func AddDMI(df *types.DataFrame, m1, m2 int) error {
+	if grouped, err := byCode(df, func(g *types.DataFrame) error { return AddDMI(g, m1, m2) }); grouped {
+		return err
+	}
+	high, low, close, err := hlc(df)
+	if err != nil {
+		return err
+	}
+
+	tr := SUM(trueRange(high, low, close), m1)
+	prevHigh, prevLow := REF(high, 1), REF(low, 1)
+	dmp := make([]float64, len(close))
+	dmm := make([]float64, len(close))
+	for i := range close {
+		hd, ld := high[i]-prevHigh[i], prevLow[i]-low[i]
+		if math.IsNaN(hd) || math.IsNaN(ld) {
+			dmp[i], dmm[i] = math.NaN(), math.NaN()
+			continue
+		}
+		if hd > 0 && hd > ld {
+			dmp[i] = hd
+		}
+		if ld > 0 && ld > hd {
+			dmm[i] = ld
+		}
+	}
+	sumDMP, sumDMM := SUM(dmp, m1), SUM(dmm, m1)
+
+	pdi := nanSlice(len(close))
+	mdi := nanSlice(len(close))
+	dx := nanSlice(len(close))
+	for i := range close {
+		if tr[i] != 0 {
+			pdi[i] = sumDMP[i] * 100 / tr[i]
+			mdi[i] = sumDMM[i] * 100 / tr[i]
+		}
+		if s := pdi[i] + mdi[i]; s != 0 {
+			dx[i] = math.Abs(mdi[i]-pdi[i]) / s * 100
+		}
+	}
+	adx := MA(dx, m2)
+	prevADX := REF(adx, m2)
+	adxr := make([]float64, len(close))
+	for i := range close {
+		adxr[i] = (adx[i] + prevADX[i]) / 2
+	}
+
+	df.SetColumn("dmi_pdi", types.FloatsToColumn(pdi))
+	df.SetColumn("dmi_mdi", types.FloatsToColumn(mdi))
+	df.SetColumn("dmi_adx", types.FloatsToColumn(adx))
+	df.SetColumn("dmi_adxr", types.FloatsToColumn(adxr))
+	return nil
+}
+
+// AddVWAP 添加成交量加权平均价列 vwap
+//
+// 以典型价格(H+L+C)/3按成交量加权；n为0时从首行开始累计，大于0时为n周期滑动窗口
+func AddVWAP(df *types.DataFrame, n int) error {
+	if grouped, err := byCode(df, func(g *types.DataFrame) error { return AddVWAP(g, n) }); grouped {
+		return err
+	}
+	high, low, close, err := hlc(df)
+	if err != nil {
+		return err
+	}
+	vol, err := column(df, colVol)
+	if err != nil {
+		return err
+	}
+
+	pv := make([]float64, len(close))
+	for i := range close {
+		pv[i] = (high[i] + low[i] + close[i]) / 3 * vol[i]
+	}
+	sumPV, sumVol := SUM(pv, n), SUM(vol, n)
+	vwap := nanSlice(len(close))
+	for i := range close {
+		if sumVol[i] != 0 {
+			vwap[i] = sumPV[i] / sumVol[i]
+		}
+	}
+
+	df.SetColumn("vwap", types.FloatsToColumn(vwap))
+	return nil
+}
@@ -0,0 +1,226 @@
+package indicators
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Premium-Platform/go-tushare/pkg/types"
+)
+
+// barsFixture 40根日线（开、高、低、收、量）
+var barsFixture = [][5]float64{
+	{10.09, 10.19, 9.92, 9.95, 21753.0},
+	{9.97, 10.04, 9.67, 9.72, 95137.0},
+	{9.82, 9.99, 9.65, 9.84, 24392.0},
+	{9.89, 10.18, 9.71, 10.09, 61549.0},
+	{10.09, 10.27, 10.05, 10.08, 50287.0},
+	{10.03, 10.1, 9.78, 9.86, 30642.0},
+	{9.85, 10.0, 9.57, 9.65, 10731.0},
+	{9.74, 9.81, 9.48, 9.61, 15626.0},
+	{9.52, 9.73, 9.45, 9.62, 95420.0},
+	{9.6, 9.77, 9.56, 9.62, 81819.0},
+	{9.53, 9.54, 9.22, 9.35, 75390.0},
+	{9.32, 9.38, 8.96, 9.14, 32502.0},
+	{9.07, 9.43, 8.95, 9.28, 85647.0},
+	{9.19, 9.25, 8.93, 8.96, 47789.0},
+	{8.98, 9.37, 8.96, 9.19, 46231.0},
+	{9.15, 9.27, 9.01, 9.02, 94522.0},
+	{8.94, 9.04, 8.76, 8.88, 25027.0},
+	{8.8, 8.87, 8.6, 8.76, 55172.0},
+	{8.71, 8.83, 8.69, 8.73, 84480.0},
+	{8.8, 8.93, 8.54, 8.63, 71060.0},
+	{8.6, 8.64, 8.37, 8.53, 11105.0},
+	{8.57, 8.69, 8.47, 8.61, 98625.0},
+	{8.67, 9.02, 8.6, 8.85, 85218.0},
+	{8.87, 9.1, 8.78, 9.01, 25726.0},
+	{9.02, 9.18, 8.79, 8.95, 52261.0},
+	{8.89, 9.03, 8.6, 8.76, 36830.0},
+	{8.83, 8.9, 8.73, 8.89, 40267.0},
+	{8.96, 9.03, 8.73, 8.75, 10122.0},
+	{8.74, 8.82, 8.63, 8.78, 64189.0},
+	{8.76, 9.03, 8.69, 8.94, 74876.0},
+	{9.01, 9.04, 8.63, 8.78, 10670.0},
+	{8.86, 8.87, 8.6, 8.63, 72573.0},
+	{8.58, 8.9, 8.47, 8.76, 20172.0},
+	{8.68, 9.03, 8.61, 8.89, 98952.0},
+	{8.94, 9.09, 8.66, 8.7, 20948.0},
+	{8.77, 9.04, 8.68, 8.88, 85285.0},
+	{8.81, 8.96, 8.48, 8.64, 94865.0},
+	{8.72, 8.78, 8.54, 8.59, 54320.0},
+	{8.52, 8.59, 8.35, 8.46, 52323.0},
+	{8.41, 8.73, 8.35, 8.63, 28348.0},
+}
+
+// referenceValues 参考值：行号 -> 期望值，NaN表示该行没有结果
+//
+// 参考值按通达信公式定义（布林线为TA-Lib BBANDS的总体标准差）逐行独立计算，不复用本包的实现：
+// EMA、SMA(X,N,M)的初始值为第一个有效值，KDJ的K'、D'初始值为50，真实波幅和DM从第二行开始
+var referenceValues = map[string]map[int]float64{
+	"macd_dif":   {0: 0, 1: -0.01834757835, 8: -0.06102039653, 13: -0.1951581818, 14: -0.2039277219, 19: -0.3074737565, 20: -0.3270696061, 25: -0.2539657106, 39: -0.1616842392},
+	"macd_dea":   {0: 0, 1: -0.00366951567, 8: -0.02487012479, 13: -0.1066672537, 14: -0.1261193474, 19: -0.2266912205, 20: -0.2467668976, 25: -0.2686651835, 39: -0.1711168328},
+	"macd":       {0: 0, 1: -0.02935612536, 8: -0.07230054348, 13: -0.1769818563, 14: -0.1556167491, 19: -0.1615650721, 20: -0.160605417, 25: 0.02939894595, 39: 0.01886518708},
+	"kdj_k":      {0: nan, 1: nan, 8: 40.24390244, 13: 16.33334917, 14: 18.98858792, 19: 12.81743629, 20: 13.57640407, 25: 50.60539752, 39: 31.74499762},
+	"kdj_d":      {0: nan, 1: nan, 8: 46.74796748, 13: 24.91851722, 14: 22.94187412, 19: 15.07408928, 20: 14.57486087, 25: 40.34670045, 39: 35.81925459},
+	"kdj_j":      {0: nan, 1: nan, 8: 27.23577236, 13: -0.8369869336, 14: 11.08201552, 19: 8.304130318, 20: 11.57949046, 25: 71.12279165, 39: 23.59648367},
+	"rsi_6":      {0: nan, 1: 0, 8: 17.78517844, 13: 16.42105326, 14: 33.44301573, 19: 18.39352437, 20: 16.05828663, 25: 40.16130487, 39: 45.80504936},
+	"boll_mid":   {0: nan, 1: nan, 8: nan, 13: nan, 14: nan, 19: 9.399, 20: 9.328, 25: 9.0575, 39: 8.7515},
+	"boll_upper": {0: nan, 1: nan, 8: nan, 13: nan, 14: nan, 19: 10.30898681, 20: 10.2757468, 25: 9.763669243, 39: 9.045729502},
+	"boll_lower": {0: nan, 1: nan, 8: nan, 13: nan, 14: nan, 19: 8.489013187, 20: 8.380253198, 25: 8.351330757, 39: 8.457270498},
+	"atr_14":     {0: nan, 1: nan, 8: nan, 13: nan, 14: 0.3592857143, 19: 0.3328571429, 20: 0.3214285714, 25: 0.3314285714, 39: 0.3328571429},
+	"cci_14":     {0: nan, 1: nan, 8: nan, 13: -150.1922508, 14: -98.19549474, 19: -115.0730099, 20: -137.3836891, 25: -30.79710145, 39: -117.6282051},
+	"wr_10":      {0: nan, 1: nan, 8: nan, 13: 97.76119403, 14: 77.77777778, 19: 91, 20: 84.90566038, 25: 51.85185185, 39: 62.16216216},
+	"dmi_pdi":    {0: nan, 1: nan, 8: nan, 13: nan, 14: 9.741550696, 19: 4.506437768, 20: 4.666666667, 25: 15.30172414, 39: 14.3776824},
+	"dmi_mdi":    {0: nan, 1: nan, 8: nan, 13: nan, 14: 29.6222664, 19: 32.40343348, 20: 32.66666667, 25: 20.25862069, 39: 15.2360515},
+	"dmi_adx":    {0: nan, 1: nan, 8: nan, 13: nan, 14: nan, 19: 60.92081286, 20: 65.00330444, 25: 41.70213857, 39: 17.75889615},
+	"dmi_adxr":   {0: nan, 1: nan, 8: nan, 13: nan, 14: nan, 19: nan, 20: nan, 25: 51.31147571, 39: 14.28323689},
+	"vwap":       {0: 10.02, 1: 9.849080589, 8: 9.839922021, 13: 9.614764315, 14: 9.588428787, 19: 9.367512625, 20: 9.359015069, 25: 9.2370732, 39: 9.071480388},
+	"ema_12":     {0: 9.95, 1: 9.914615385, 8: 9.815931657, 13: 9.48115076, 14: 9.436358336, 19: 9.061159317, 20: 8.979442499, 25: 8.904014657, 39: 8.700927941},
+	"sma_5":      {0: nan, 1: nan, 8: 9.764, 13: 9.27, 14: 9.184, 19: 8.804, 20: 8.706, 25: 8.836, 39: 8.64},
+	"wma_10":     {0: nan, 1: nan, 8: nan, 13: 9.358181818, 14: 9.298727273, 19: 8.881636364, 20: 8.797272727, 25: 8.792727273, 39: 8.66},
+	"obv":        {0: 0, 1: -95137, 8: -21062, 13: -91096, 14: -44865, 19: -375126, 20: -386231, 25: -265753, 39: -169485},
+}
+
+var nan = math.NaN()
+
+// fixtureFrame 创建测试用的DataFrame
+func fixtureFrame(code string) *types.DataFrame {
+	rows := make([]map[string]interface{}, len(barsFixture))
+	for i, b := range barsFixture {
+		rows[i] = map[string]interface{}{
+			"ts_code": code,
+			"open":    b[0],
+			"high":    b[1],
+			"low":     b[2],
+			"close":   b[3],
+			"vol":     b[4],
+		}
+	}
+	return types.NewDataFrame([]string{"ts_code", "open", "high", "low", "close", "vol"}, rows)
+}
+
+// assertReference 检查结果列与参考值一致
+func assertReference(t *testing.T, df *types.DataFrame, offset int, col string) {
+	t.Helper()
+	want, ok := referenceValues[col]
+	if !ok {
+		t.Fatalf("no reference values for %s", col)
+	}
+	if !df.HasColumn(col) {
+		t.Fatalf("missing column %s", col)
+	}
+	for row, w := range want {
+		got, ok := types.ToFloat64(df.Rows[offset+row][col])
+		switch {
+		case math.IsNaN(w):
+			if ok {
+				t.Errorf("%s[%d] = %v, want nil", col, row, got)
+			}
+		case !ok:
+			t.Errorf("%s[%d] = nil, want %v", col, row, w)
+		case math.Abs(got-w) > 1e-6*math.Max(1, math.Abs(w)):
+			t.Errorf("%s[%d] = %.10g, want %.10g", col, row, got, w)
+		}
+	}
+}
+
+// referenceSpecs 指标描述及其输出列
+var referenceSpecs = []struct {
+	spec    string
+	columns []string
+}{
+	{"ema(12)", []string{"ema_12"}},
+	{"sma(5)", []string{"sma_5"}},
+	{"wma(10)", []string{"wma_10"}},
+	{"macd(12,26,9)", []string{"macd_dif", "macd_dea", "macd"}},
+	{"kdj(9,3,3)", []string{"kdj_k", "kdj_d", "kdj_j"}},
+	{"rsi(6)", []string{"rsi_6"}},
+	{"boll(20,2)", []string{"boll_upper", "boll_mid", "boll_lower"}},
+	{"atr(14)", []string{"atr_14"}},
+	{"obv", []string{"obv"}},
+	{"cci(14)", []string{"cci_14"}},
+	{"wr(10)", []string{"wr_10"}},
+	{"dmi(14,6)", []string{"dmi_pdi", "dmi_mdi", "dmi_adx", "dmi_adxr"}},
+	{"vwap", []string{"vwap"}},
+}
+
+func TestReferenceValues(t *testing.T) {
+	for _, tt := range referenceSpecs {
+		t.Run(tt.spec, func(t *testing.T) {
+			df := fixtureFrame("000001.SZ")
+			if err := Apply(df, tt.spec); err != nil {
+				t.Fatal(err)
+			}
+			for _, col := range tt.columns {
+				assertReference(t, df, 0, col)
+			}
+		})
+	}
+}
+
+// TestMultiCode 多证券长表按证券分别计算，结果与单独计算相同
+func TestMultiCode(t *testing.T) {
+	for _, tt := range referenceSpecs {
+		t.Run(tt.spec, func(t *testing.T) {
+			df := types.Concat(fixtureFrame("000001.SZ"), fixtureFrame("600000.SH"))
+			if err := Apply(df, tt.spec); err != nil {
+				t.Fatal(err)
+			}
+			for _, col := range tt.columns {
+				assertReference(t, df, 0, col)
+				assertReference(t, df, len(barsFixture), col)
+			}
+		})
+	}
+}
+
+func TestApplyInvalid(t *testing.T) {
+	for _, spec := range []string{"unknown", "macd(12,26", "rsi(0)", "rsi(1.5)", "ema(1,2)", "rsi(-1)"} {
+		if err := Apply(fixtureFrame("000001.SZ"), spec); err == nil {
+			t.Errorf("Apply(%q) expected error", spec)
+		}
+	}
+}
+
+// TestRollingSeries 序列滑动计算与DataFrame滑动窗口结果一致，缺失值不参与计算
+func TestRollingSeries(t *testing.T) {
+	values := []float64{3, 1, math.NaN(), 4, 1, 5, math.NaN(), math.NaN(), 9, 2, 6}
+	rows := make([]map[string]interface{}, len(values))
+	for i, v := range values {
+		rows[i] = map[string]interface{}{"v": v}
+	}
+	df := types.NewDataFrame([]string{"v"}, rows)
+
+	tests := []struct {
+		name string
+		got  []float64
+		want []interface{}
+	}{
+		{"MA", MA(values, 3), df.Rolling("v", 3).Mean()},
+		{"SUM", SUM(values, 2), df.Rolling("v", 2).Sum()},
+		{"SUM(0)", SUM(values, 0), df.Expanding("v").Sum()},
+		{"HHV", HHV(values, 3), df.Rolling("v", 3).Max()},
+		{"LLV", LLV(values, 2), df.Rolling("v", 2).Min()},
+		{"MA(1)", MA(values, 0), df.Rolling("v", 1).Mean()},
+	}
+	for _, tt := range tests {
+		for i, w := range tt.want {
+			g := tt.got[i]
+			wf, ok := types.ToFloat64(w)
+			if !ok {
+				if !math.IsNaN(g) {
+					t.Errorf("%s[%d] = %v, want NaN", tt.name, i, g)
+				}
+				continue
+			}
+			if math.Abs(g-wf) > 1e-9 {
+				t.Errorf("%s[%d] = %v, want %v", tt.name, i, g, wf)
+			}
+		}
+	}
+
+	// 窗口内存在缺失值时STD为NaN
+	std := STD(values, 2)
+	if !math.IsNaN(std[2]) || !math.IsNaN(std[3]) || math.Abs(std[5]-2) > 1e-9 {
+		t.Errorf("STD = %v, want NaN at 2 and 3 and 2 at 5", std)
+	}
+}
@@ -0,0 +1,142 @@
+package indicators
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	tsError "github.com/Premium-Platform/go-tushare/pkg/errors"
+	"github.com/Premium-Platform/go-tushare/pkg/types"
+)
+
+// Spec 指标描述，如 macd(12,26,9)
+type Spec struct {
+	Name string    // 指标名称（小写）
+	Args []float64 // 指标参数，未指定的参数使用默认值
+}
+
+// indicator 指标定义
+type indicator struct {
+	defaults []float64
+	apply    func(df *types.DataFrame, args []float64) error
+}
+
+// registry 已注册的指标
+var registry = map[string]indicator{
+	"ema": {[]float64{12}, func(df *types.DataFrame, a []float64) error {
+		return AddEMA(df, int(a[0]))
+	}},
+	"sma": {[]float64{5}, func(df *types.DataFrame, a []float64) error {
+		return AddSMA(df, int(a[0]))
+	}},
+	"wma": {[]float64{10}, func(df *types.DataFrame, a []float64) error {
+		return AddWMA(df, int(a[0]))
+	}},
+	"macd": {[]float64{12, 26, 9}, func(df *types.DataFrame, a []float64) error {
+		return AddMACD(df, int(a[0]), int(a[1]), int(a[2]))
+	}},
+	"kdj": {[]float64{9, 3, 3}, func(df *types.DataFrame, a []float64) error {
+		return AddKDJ(df, int(a[0]), int(a[1]), int(a[2]))
+	}},
+	"rsi": {[]float64{6}, func(df *types.DataFrame, a []float64) error {
+		return AddRSI(df, int(a[0]))
+	}},
+	"boll": {[]float64{20, 2}, func(df *types.DataFrame, a []float64) error {
+		return AddBOLL(df, int(a[0]), a[1])
+	}},
+	"atr": {[]float64{14}, func(df *types.DataFrame, a []float64) error {
+		return AddATR(df, int(a[0]))
+	}},
+	"obv": {nil, func(df *types.DataFrame, a []float64) error {
+		return AddOBV(df)
+	}},
+	"cci": {[]float64{14}, func(df *types.DataFrame, a []float64) error {
+		return AddCCI(df, int(a[0]))
+	}},
+	"wr": {[]float64{10}, func(df *types.DataFrame, a []float64) error {
+		return AddWR(df, int(a[0]))
+	}},
+	"dmi": {[]float64{14, 6}, func(df *types.DataFrame, a []float64) error {
+		return AddDMI(df, int(a[0]), int(a[1]))
+	}},
+	"vwap": {[]float64{0}, func(df *types.DataFrame, a []float64) error {
+		return AddVWAP(df, int(a[0]))
+	}},
+}
+
+// Names 返回支持的指标名称
+func Names() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Parse 解析指标描述，如 "macd"、"macd(12,26,9)"、"rsi(14)"
+func Parse(spec string) (Spec, error) {
+	spec = strings.ToLower(strings.TrimSpace(spec))
+	name := spec
+	var args []float64
+
+	if idx := strings.Index(spec, "("); idx >= 0 {
+		if !strings.HasSuffix(spec, ")") {
+			return Spec{}, tsError.Wrapf(tsError.ErrInvalidParameter, "invalid indicator %q", spec)
+		}
+		name = strings.TrimSpace(spec[:idx])
+		inner := strings.TrimSpace(spec[idx+1 : len(spec)-1])
+		if inner != "" {
+			for _, part := range strings.Split(inner, ",") {
+				f, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+				if err != nil || f < 0 {
+					return Spec{}, tsError.Wrapf(tsError.ErrInvalidParameter, "invalid indicator argument %q in %q", part, spec)
+				}
+				args = append(args, f)
+			}
+		}
+	}
+
+	if name == "" {
+		return Spec{}, tsError.Wrapf(tsError.ErrInvalidParameter, "invalid indicator %q", spec)
+	}
+	return Spec{Name: name, Args: args}, nil
+}
+
+// Apply 按指标描述计算指标并将结果列写入DataFrame
+//
+// DataFrame需按时间升序排列；包含多个ts_code的长表（如BarMulti的结果）按证券分别计算
+func Apply(df *types.DataFrame, spec string) error {
+	s, err := Parse(spec)
+	if err != nil {
+		return err
+	}
+
+	ind, ok := registry[s.Name]
+	if !ok {
+		return tsError.Wrapf(tsError.ErrInvalidParameter, "unknown indicator %q", s.Name)
+	}
+	if len(s.Args) > len(ind.defaults) {
+		return tsError.Wrapf(tsError.ErrInvalidParameter, "too many arguments for indicator %q", s.Name)
+	}
+
+	args := make([]float64, len(ind.defaults))
+	copy(args, ind.defaults)
+	copy(args, s.Args)
+	for i, a := range args {
+		// 布林线的倍数参数可以为小数，其余参数均为周期
+		if s.Name == "boll" && i == 1 {
+			continue
+		}
+		// 成交量加权平均价的周期为0时表示累计
+		minPeriod := 1.0
+		if s.Name == "vwap" {
+			minPeriod = 0
+		}
+		if a < minPeriod || a != float64(int(a)) {
+			return tsError.Wrapf(tsError.ErrInvalidParameter, "invalid period %v for indicator %q", a, s.Name)
+		}
+	}
+
+	return ind.apply(df, args)
+}
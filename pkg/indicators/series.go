@@ -0,0 +1,211 @@
+package indicators
+
+import (
+	"math"
+)
+
+// rollingSum 滑动求和或均值，窗口按位置计数，NaN不参与计算
+//
+// n为0时为累计窗口；窗口内有效值个数少于minPeriods时结果为NaN
+func rollingSum(values []float64, n, minPeriods int, mean bool) []float64 {
+	result := nanSlice(len(values))
+	var sum float64
+	count := 0
+	for i, v := range values {
+		if !math.IsNaN(v) {
+			sum += v
+			count++
+		}
+		if n > 0 && i >= n {
+			if old := values[i-n]; !math.IsNaN(old) {
+				sum -= old
+				count--
+			}
+		}
+		if count >= minPeriods {
+			if mean {
+				result[i] = sum / float64(count)
+			} else {
+				result[i] = sum
+			}
+		}
+	}
+	return result
+}
+
+// rollingExtreme 使用单调队列计算滑动极值，better(a, b)为true时a优于b，窗口内有效值不足n个时结果为NaN
+func rollingExtreme(values []float64, n int, better func(a, b float64) bool) []float64 {
+	result := nanSlice(len(values))
+	deque := make([]int, 0, n)
+	count := 0
+	for i, v := range values {
+		if i >= n {
+			if !math.IsNaN(values[i-n]) {
+				count--
+			}
+			if len(deque) > 0 && deque[0] <= i-n {
+				deque = deque[1:]
+			}
+		}
+		if !math.IsNaN(v) {
+			count++
+			for len(deque) > 0 && better(v, values[deque[len(deque)-1]]) {
+				deque = deque[:len(deque)-1]
+			}
+			deque = append(deque, i)
+		}
+		if count >= n && len(deque) > 0 {
+			result[i] = values[deque[0]]
+		}
+	}
+	return result
+}
+
+// rollingApply 对每个滑动窗口内的有效值调用fn，窗口内有效值不足n个时结果为NaN
+func rollingApply(values []float64, n int, fn func(window []float64) float64) []float64 {
+	result := nanSlice(len(values))
+	window := make([]float64, 0, n)
+	for i := n - 1; i < len(values); i++ {
+		window = window[:0]
+		for _, v := range values[i-n+1 : i+1] {
+			if !math.IsNaN(v) {
+				window = append(window, v)
+			}
+		}
+		if len(window) == n {
+			result[i] = fn(window)
+		}
+	}
+	return result
+}
+
+// windowSize 滑动窗口大小，至少为1
+func windowSize(n int) int {
+	if n < 1 {
+		return 1
+	}
+	return n
+}
+
+// nanSlice 创建全部为NaN的切片
+func nanSlice(n int) []float64 {
+	result := make([]float64, n)
+	for i := range result {
+		result[i] = math.NaN()
+	}
+	return result
+}
+
+// MA 简单移动平均
+func MA(values []float64, n int) []float64 {
+	n = windowSize(n)
+	return rollingSum(values, n, n, true)
+}
+
+// SUM 滑动求和，n为0时为累计求和
+func SUM(values []float64, n int) []float64 {
+	if n <= 0 {
+		return rollingSum(values, 0, 1, false)
+	}
+	return rollingSum(values, n, n, false)
+}
+
+// HHV 滑动最高值
+func HHV(values []float64, n int) []float64 {
+	return rollingExtreme(values, windowSize(n), func(a, b float64) bool { return a >= b })
+}
+
+// LLV 滑动最低值
+func LLV(values []float64, n int) []float64 {
+	return rollingExtreme(values, windowSize(n), func(a, b float64) bool { return a <= b })
+}
+
+// STD 滑动总体标准差（自由度n）
+func STD(values []float64, n int) []float64 {
+	return rollingApply(values, windowSize(n), func(window []float64) float64 {
+		mean := 0.0
+		for _, v := range window {
+			mean += v
+		}
+		mean /= float64(len(window))
+		variance := 0.0
+		for _, v := range window {
+			variance += (v - mean) * (v - mean)
+		}
+		return math.Sqrt(variance / float64(len(window)))
+	})
+}
+
+// AVEDEV 滑动平均绝对偏差
+func AVEDEV(values []float64, n int) []float64 {
+	return rollingApply(values, windowSize(n), func(window []float64) float64 {
+		mean := 0.0
+		for _, v := range window {
+			mean += v
+		}
+		mean /= float64(len(window))
+		dev := 0.0
+		for _, v := range window {
+			dev += math.Abs(v - mean)
+		}
+		return dev / float64(len(window))
+	})
+}
+
+// REF 引用n周期前的值
+func REF(values []float64, n int) []float64 {
+	result := nanSlice(len(values))
+	for i := range values {
+		if i-n >= 0 && i-n < len(values) {
+			result[i] = values[i-n]
+		}
+	}
+	return result
+}
+
+// EMA 指数移动平均，alpha = 2/(n+1)，以第一个有效值为初始值
+func EMA(values []float64, n int) []float64 {
+	return smooth(values, 2.0/float64(n+1), math.NaN())
+}
+
+// SMA 通达信风格的移动平均 Y = (m×X + (n-m)×Y')/n，第一个有效值处没有Y'，取Y = X
+func SMA(values []float64, n, m int) []float64 {
+	return smooth(values, float64(m)/float64(n), math.NaN())
+}
+
+// WMA 加权移动平均，权重依次为1..n，窗口内存在缺失值时结果为NaN
+func WMA(values []float64, n int) []float64 {
+	result := nanSlice(len(values))
+	denom := float64(n*(n+1)) / 2
+	for i := n - 1; i < len(values); i++ {
+		sum := 0.0
+		for j := 0; j < n; j++ {
+			v := values[i-n+1+j]
+			if math.IsNaN(v) {
+				sum = math.NaN()
+				break
+			}
+			sum += v * float64(j+1)
+		}
+		result[i] = sum / denom
+	}
+	return result
+}
+
+// smooth 指数平滑，缺失值处输出NaN且不更新状态；seed为第一个有效值之前的Y'，为NaN时以第一个有效值为初始值
+func smooth(values []float64, alpha, seed float64) []float64 {
+	result := nanSlice(len(values))
+	prev := seed
+	for i, v := range values {
+		if math.IsNaN(v) {
+			continue
+		}
+		if math.IsNaN(prev) {
+			prev = v
+		} else {
+			prev = alpha*v + (1-alpha)*prev
+		}
+		result[i] = prev
+	}
+	return result
+}
@@ -46,8 +46,11 @@ var (
 	defaultLogger = NewLogger(os.Stdout, INFO)
 )
 
-// NewLogger 创建一个新的日志记录器
+// NewLogger 创建一个新的日志记录器，writer为nil时丢弃日志输出
 func NewLogger(writer io.Writer, level Level) *Logger {
+	if writer == nil {
+		writer = io.Discard
+	}
 	return &Logger{
 		level:  level,
 		writer: writer,
@@ -71,6 +74,9 @@ func (l *Logger) GetLevel() Level {
 
 // SetOutput 设置日志输出
 func (l *Logger) SetOutput(writer io.Writer) {
+	if writer == nil {
+		writer = io.Discard
+	}
 	l.mu.Lock()
 	defer l.mu.Unlock()
 	l.writer = writer
@@ -0,0 +1,417 @@
+package resample
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	tsError "github.com/Premium-Platform/go-tushare/pkg/errors"
+	"github.com/Premium-Platform/go-tushare/pkg/session"
+	"github.com/Premium-Platform/go-tushare/pkg/types"
+)
+
+const (
+	// PartialKeep 保留末尾不完整周期，并通过is_partial列标记
+	PartialKeep = "keep"
+	// PartialDrop 丢弃末尾不完整周期
+	PartialDrop = "drop"
+)
+
+// Options 重采样选项
+type Options struct {
+	Rule        Rule             // 重采样规则
+	TradingDays []string         // 交易日历（YYYYMMDD），用于交易日周期对齐和判断末尾周期是否完整
+	Session     *session.Session // 日内交易时段，默认为A股时段（跳过午休）
+	Partial     string           // 末尾不完整周期的处理方式，默认PartialKeep
+	GroupBy     string           // 分组列，默认存在ts_code列时按ts_code分组
+}
+
+// outputColumns 重采样结果中可能出现的列（按输出顺序）
+var outputColumns = []string{
+	"ts_code", "trade_time", "trade_date", "open", "high", "low", "close",
+	"pre_close", "change", "pct_chg", "vol", "amount",
+}
+
+// Resample 将K线数据重采样为更长的周期
+//
+// 聚合规则：开盘价取首个、最高价取最大、最低价取最小、收盘价取最后一个、成交量和成交额求和；
+// 昨收价取周期内首根K线的昨收价，涨跌额和涨跌幅按聚合结果重新计算。
+//
+// 日内周期在交易时段内按分钟数切分，跳过午休，K线以周期结束时间标记（如60min为10:30、11:30、14:00、15:00）；
+// 交易日周期（ND）按交易日历计数；周、月周期按自然周、自然月划分，季度、年按自然季度、自然年对齐。
+// 日线以上周期以周期内最后一个交易日标记。
+//
+// 末尾周期的数据不足一个完整周期时视为不完整周期，按Partial处理。未提供交易日历时，
+// 周、月周期无法判断是否完整，视为完整。
+func Resample(df *types.DataFrame, opts Options) (*types.DataFrame, error) {
+	if df == nil {
+		return nil, nil
+	}
+	if opts.Rule.N <= 0 {
+		return nil, tsError.Wrap(tsError.ErrInvalidParameter, "resample rule is required")
+	}
+	if opts.Session == nil {
+		opts.Session = session.AShare
+	}
+	if opts.Partial == "" {
+		opts.Partial = PartialKeep
+	}
+	if opts.GroupBy == "" && df.HasColumn("ts_code") {
+		opts.GroupBy = "ts_code"
+	}
+
+	timeCol := "trade_date"
+	if opts.Rule.Intraday() {
+		timeCol = "trade_time"
+	}
+	if !df.HasColumn(timeCol) {
+		return nil, tsError.Wrapf(tsError.ErrInvalidParameter, "missing column %s", timeCol)
+	}
+
+	sorted := df.Copy().SortBy(timeCol, true)
+
+	columns := make([]string, 0, len(outputColumns)+1)
+	for _, col := range outputColumns {
+		if sorted.HasColumn(col) || (col == "trade_date" && opts.Rule.Intraday()) {
+			columns = append(columns, col)
+		}
+	}
+	if opts.Partial == PartialKeep {
+		columns = append(columns, "is_partial")
+	}
+
+	rows := make([]map[string]interface{}, 0)
+	for _, group := range groupRows(sorted, opts.GroupBy) {
+		var buckets []bucket
+		var err error
+		if opts.Rule.Intraday() {
+			buckets, err = intradayBuckets(group, opts)
+		} else {
+			buckets, err = dailyBuckets(group, opts)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, b := range buckets {
+			if b.partial && opts.Partial == PartialDrop {
+				continue
+			}
+			row := aggregate(b.rows, sorted)
+			if opts.Rule.Intraday() {
+				row["trade_time"] = b.label
+				row["trade_date"] = b.date
+			} else {
+				row["trade_date"] = b.label
+			}
+			if opts.Partial == PartialKeep {
+				row["is_partial"] = b.partial
+			}
+			rows = append(rows, row)
+		}
+	}
+
+	return types.NewDataFrame(columns, rows), nil
+}
+
+// bucket 一个重采样周期
+type bucket struct {
+	rows    []map[string]interface{}
+	label   string // 周期标记（日内为结束时间，日线以上为最后一个交易日）
+	date    string // 交易日期（日内周期）
+	partial bool
+}
+
+// groupRows 按列分组，组按首次出现顺序排列，组内保持原始行序
+func groupRows(df *types.DataFrame, col string) [][]map[string]interface{} {
+	if col == "" {
+		return [][]map[string]interface{}{df.Rows}
+	}
+
+	order := make([]interface{}, 0)
+	groups := make(map[interface{}][]map[string]interface{})
+	for _, row := range df.Rows {
+		key := row[col]
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], row)
+	}
+
+	result := make([][]map[string]interface{}, 0, len(order))
+	for _, key := range order {
+		result = append(result, groups[key])
+	}
+	return result
+}
+
+// aggregate 聚合一个周期内的K线
+func aggregate(rows []map[string]interface{}, df *types.DataFrame) map[string]interface{} {
+	result := make(map[string]interface{})
+	first, last := rows[0], rows[len(rows)-1]
+
+	if df.HasColumn("ts_code") {
+		result["ts_code"] = first["ts_code"]
+	}
+
+	var open, high, low, close interface{}
+	var vol, amount float64
+	hasVol, hasAmount := false, false
+	for _, row := range rows {
+		if v, ok := types.ToFloat64(row["open"]); ok && open == nil {
+			open = v
+		}
+		if v, ok := types.ToFloat64(row["high"]); ok {
+			if h, _ := types.ToFloat64(high); high == nil || v > h {
+				high = v
+			}
+		}
+		if v, ok := types.ToFloat64(row["low"]); ok {
+			if l, _ := types.ToFloat64(low); low == nil || v < l {
+				low = v
+			}
+		}
+		if v, ok := types.ToFloat64(row["close"]); ok {
+			close = v
+		}
+		if v, ok := types.ToFloat64(row["vol"]); ok {
+			vol += v
+			hasVol = true
+		}
+		if v, ok := types.ToFloat64(row["amount"]); ok {
+			amount += v
+			hasAmount = true
+		}
+	}
+
+	result["open"], result["high"], result["low"], result["close"] = open, high, low, close
+	if hasVol {
+		result["vol"] = vol
+	} else if df.HasColumn("vol") {
+		result["vol"] = nil
+	}
+	if hasAmount {
+		result["amount"] = amount
+	} else if df.HasColumn("amount") {
+		result["amount"] = nil
+	}
+
+	if df.HasColumn("pre_close") {
+		result["pre_close"] = first["pre_close"]
+		preClose, okPre := types.ToFloat64(first["pre_close"])
+		c, okClose := types.ToFloat64(close)
+		if df.HasColumn("change") {
+			result["change"] = nil
+			if okPre && okClose {
+				result["change"] = c - preClose
+			}
+		}
+		if df.HasColumn("pct_chg") {
+			result["pct_chg"] = nil
+			if okPre && okClose && preClose != 0 {
+				result["pct_chg"] = (c - preClose) / preClose * 100
+			}
+		}
+	} else {
+		for _, col := range []string{"change", "pct_chg"} {
+			if df.HasColumn(col) {
+				result[col] = last[col]
+			}
+		}
+	}
+
+	return result
+}
+
+// formatClock 将当日分钟数格式化为HH:MM:00
+func formatClock(m int) string {
+	return time.Date(0, 1, 1, m/60, m%60, 0, 0, time.UTC).Format("15:04:05")
+}
+
+// intradayBuckets 按交易时段切分日内周期
+func intradayBuckets(rows []map[string]interface{}, opts Options) ([]bucket, error) {
+	n := opts.Rule.N
+	total := opts.Session.Length()
+
+	buckets := make([]bucket, 0)
+	keys := make(map[string]int)
+	for _, row := range rows {
+		s, _ := row["trade_time"].(string)
+		t, err := time.Parse("2006-01-02 15:04:05", s)
+		if err != nil {
+			return nil, tsError.Wrapf(tsError.ErrInvalidParameter, "invalid trade_time %q", s)
+		}
+
+		// 分钟K线以结束时间标记，09:30集合竞价归入第一个周期
+		elapsed := opts.Session.Elapsed(t.Hour()*60 + t.Minute())
+		idx := 0
+		if elapsed > 0 {
+			idx = (elapsed - 1) / n
+		}
+
+		date := t.Format("20060102")
+		key := date + "/" + strconv.Itoa(idx)
+		pos, ok := keys[key]
+		if !ok {
+			end := (idx + 1) * n
+			if end > total {
+				end = total
+			}
+			buckets = append(buckets, bucket{
+				label: t.Format("2006-01-02") + " " + formatClock(opts.Session.Clock(end)),
+				date:  date,
+			})
+			pos = len(buckets) - 1
+			keys[key] = pos
+		}
+		buckets[pos].rows = append(buckets[pos].rows, row)
+	}
+
+	// 末尾周期最后一根K线未到达周期结束时间时为不完整周期
+	if len(buckets) > 0 {
+		last := &buckets[len(buckets)-1]
+		lastTime, _ := last.rows[len(last.rows)-1]["trade_time"].(string)
+		last.partial = lastTime < last.label
+	}
+
+	return buckets, nil
+}
+
+// dailyBuckets 按交易日、自然周、自然月切分日线以上周期
+func dailyBuckets(rows []map[string]interface{}, opts Options) ([]bucket, error) {
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	dates := make([]time.Time, len(rows))
+	for i, row := range rows {
+		s, _ := row["trade_date"].(string)
+		t, err := time.Parse("20060102", s)
+		if err != nil {
+			return nil, tsError.Wrapf(tsError.ErrInvalidParameter, "invalid trade_date %q", s)
+		}
+		dates[i] = t
+	}
+
+	calendar := opts.TradingDays
+	if !sort.StringsAreSorted(calendar) {
+		calendar = append([]string(nil), calendar...)
+		sort.Strings(calendar)
+	}
+	calIndex := make(map[string]int, len(calendar))
+	for i, d := range calendar {
+		calIndex[d] = i
+	}
+
+	keyOf, err := bucketKeyFunc(opts.Rule, dates[0], calIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	buckets := make([]bucket, 0)
+	keys := make(map[int]int)
+	for i, row := range rows {
+		key, ok := keyOf(dates[i], i)
+		if !ok {
+			return nil, tsError.Wrapf(tsError.ErrInvalidParameter, "trade_date %s is not in the trading calendar", dates[i].Format("20060102"))
+		}
+		pos, exists := keys[key]
+		if !exists {
+			buckets = append(buckets, bucket{})
+			pos = len(buckets) - 1
+			keys[key] = pos
+		}
+		buckets[pos].rows = append(buckets[pos].rows, row)
+		buckets[pos].label = dates[i].Format("20060102")
+	}
+
+	// 判断末尾周期是否完整
+	last := &buckets[len(buckets)-1]
+	lastKey, _ := keyOf(dates[len(dates)-1], len(dates)-1)
+	switch {
+	case len(calendar) > 0:
+		// 交易日历中该周期最后一个交易日之后还有交易日，才能确认周期已经结束
+		lastDay, closed := "", false
+		for _, d := range calendar {
+			t, _ := time.Parse("20060102", d)
+			k, ok := keyOf(t, -1)
+			if !ok || d < rows[0]["trade_date"].(string) {
+				continue
+			}
+			if k == lastKey {
+				lastDay = d
+			} else if k > lastKey {
+				closed = true
+				break
+			}
+		}
+		last.partial = !closed || last.label < lastDay
+	case opts.Rule.Unit == UnitDay:
+		last.partial = len(last.rows) < opts.Rule.N
+	}
+
+	return buckets, nil
+}
+
+// bucketKeyFunc 返回计算日期所属周期编号的函数
+//
+// row为行号，仅在没有交易日历的交易日周期中使用（按行计数），传入-1表示日历中的日期
+func bucketKeyFunc(rule Rule, anchor time.Time, calIndex map[string]int) (func(t time.Time, row int) (int, bool), error) {
+	n := rule.N
+
+	switch rule.Unit {
+	case UnitDay:
+		if len(calIndex) == 0 {
+			return func(t time.Time, row int) (int, bool) {
+				if row < 0 {
+					return 0, false
+				}
+				return row / n, true
+			}, nil
+		}
+		start, ok := calIndex[anchor.Format("20060102")]
+		if !ok {
+			return nil, tsError.Wrapf(tsError.ErrInvalidParameter, "trade_date %s is not in the trading calendar", anchor.Format("20060102"))
+		}
+		return func(t time.Time, row int) (int, bool) {
+			idx, ok := calIndex[t.Format("20060102")]
+			if !ok || idx < start {
+				return 0, false
+			}
+			return (idx - start) / n, true
+		}, nil
+
+	case UnitWeek:
+		monday := func(t time.Time) time.Time {
+			return t.AddDate(0, 0, -((int(t.Weekday()) + 6) % 7))
+		}
+		anchorMonday := monday(anchor)
+		return func(t time.Time, row int) (int, bool) {
+			weeks := int(monday(t).Sub(anchorMonday).Hours()/24) / 7
+			if weeks < 0 {
+				return 0, false
+			}
+			return weeks / n, true
+		}, nil
+
+	case UnitMonth:
+		months := func(t time.Time) int {
+			return t.Year()*12 + int(t.Month()) - 1
+		}
+		// 能整除12的周期（季度、半年、年）按自然年对齐，其余从首个月份开始计数
+		base := 0
+		if 12%n != 0 {
+			base = months(anchor)
+		}
+		first := (months(anchor) - base) / n
+		return func(t time.Time, row int) (int, bool) {
+			k := (months(t) - base) / n
+			if k < first {
+				return 0, false
+			}
+			return k, true
+		}, nil
+	}
+
+	return nil, tsError.Wrapf(tsError.ErrInvalidParameter, "invalid resample rule %s", rule)
+}
@@ -0,0 +1,268 @@
+package resample
+
+import (
+	"testing"
+
+	tsError "github.com/Premium-Platform/go-tushare/pkg/errors"
+	"github.com/Premium-Platform/go-tushare/pkg/session"
+	"github.com/Premium-Platform/go-tushare/pkg/types"
+)
+
+// dailyFrame 构造日线数据：每行为交易日期、开高低收、昨收、成交量、成交额
+func dailyFrame(rows [][]interface{}) *types.DataFrame {
+	columns := []string{"ts_code", "trade_date", "open", "high", "low", "close", "pre_close", "change", "pct_chg", "vol", "amount"}
+	df := types.NewDataFrame(columns, nil)
+	for _, r := range rows {
+		df.Rows = append(df.Rows, map[string]interface{}{
+			"ts_code": "000001.SZ", "trade_date": r[0],
+			"open": r[1], "high": r[2], "low": r[3], "close": r[4], "pre_close": r[5],
+			"change": 0.0, "pct_chg": 0.0, "vol": r[6], "amount": r[7],
+		})
+	}
+	return df
+}
+
+// filterRows 筛选满足条件的行
+func filterRows(df *types.DataFrame, keep func(row map[string]interface{}) bool) *types.DataFrame {
+	result := types.NewDataFrame(df.Columns, nil)
+	for _, row := range df.Rows {
+		if keep(row) {
+			result.Rows = append(result.Rows, row)
+		}
+	}
+	return result
+}
+
+func mustRule(t *testing.T, s string) Rule {
+	t.Helper()
+	rule, err := ParseRule(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rule
+}
+
+// TestResampleOHLCV 开盘价取首个、最高价取最大、最低价取最小、收盘价取最后一个、成交量和成交额求和
+func TestResampleOHLCV(t *testing.T) {
+	// 降序传入，结果按时间升序
+	df := dailyFrame([][]interface{}{
+		{"20240108", 11.0, 11.5, 10.8, 11.2, 10.9, 500.0, 5000.0},
+		{"20240105", 10.6, 11.0, 10.5, 10.9, 10.6, 400.0, 4000.0},
+		{"20240104", 10.3, 10.8, 10.2, 10.6, 10.3, 300.0, 3000.0},
+		{"20240103", 10.1, 10.4, 9.8, 10.3, 10.1, 200.0, 2000.0},
+		{"20240102", 10.0, 10.2, 9.9, 10.1, 10.0, 100.0, 1000.0},
+	})
+	calendar := []string{"20240102", "20240103", "20240104", "20240105", "20240108", "20240109", "20240110", "20240111", "20240112"}
+
+	result, err := Resample(df, Options{Rule: mustRule(t, "W"), TradingDays: calendar})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Rows) != 2 {
+		t.Fatalf("got %d rows, want 2", len(result.Rows))
+	}
+
+	week := result.Rows[0]
+	want := map[string]interface{}{
+		"ts_code": "000001.SZ", "trade_date": "20240105",
+		"open": 10.0, "high": 11.0, "low": 9.8, "close": 10.9,
+		"pre_close": 10.0, "vol": 1000.0, "amount": 10000.0, "is_partial": false,
+	}
+	for col, v := range want {
+		if week[col] != v {
+			t.Errorf("week %s = %v, want %v", col, week[col], v)
+		}
+	}
+	if change, _ := week["change"].(float64); change < 0.8999 || change > 0.9001 {
+		t.Errorf("change = %v, want 0.9", week["change"])
+	}
+	if pct, _ := week["pct_chg"].(float64); pct < 8.999 || pct > 9.001 {
+		t.Errorf("pct_chg = %v, want 9", week["pct_chg"])
+	}
+
+	// 第二周只有周一的数据，日历中本周还有交易日，为不完整周期
+	if result.Rows[1]["trade_date"] != "20240108" || result.Rows[1]["is_partial"] != true {
+		t.Errorf("second week = %v, want partial 20240108", result.Rows[1])
+	}
+	if !result.HasColumn("is_partial") {
+		t.Error("missing is_partial column")
+	}
+
+	// 丢弃不完整周期时不输出is_partial列
+	result, err = Resample(df, Options{Rule: mustRule(t, "W"), TradingDays: calendar, Partial: PartialDrop})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Rows) != 1 || result.HasColumn("is_partial") {
+		t.Errorf("drop partial: %d rows, columns %v", len(result.Rows), result.Columns)
+	}
+
+	// 日历在本周内结束时无法确认周期已经结束，视为不完整
+	result, err = Resample(df, Options{Rule: mustRule(t, "W"), TradingDays: calendar[:5]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Rows[1]["is_partial"] != true {
+		t.Error("week without a later trading day should be partial")
+	}
+
+	// 日历中本周之后还有交易日时末尾周期完整
+	result, err = Resample(filterRows(df, func(row map[string]interface{}) bool {
+		return row["trade_date"].(string) < "20240108"
+	}), Options{Rule: mustRule(t, "W"), TradingDays: calendar})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Rows) != 1 || result.Rows[0]["is_partial"] != false {
+		t.Errorf("complete week: %v", result.Rows)
+	}
+}
+
+// TestResampleTradingDays 交易日周期没有日历时按行计数，末尾不足N行为不完整周期
+func TestResampleTradingDays(t *testing.T) {
+	df := dailyFrame([][]interface{}{
+		{"20240102", 1.0, 1.0, 1.0, 1.0, 1.0, 1.0, 1.0},
+		{"20240103", 2.0, 2.0, 2.0, 2.0, 1.0, 1.0, 1.0},
+		{"20240104", 3.0, 3.0, 3.0, 3.0, 2.0, 1.0, 1.0},
+		{"20240105", 4.0, 4.0, 4.0, 4.0, 3.0, 1.0, 1.0},
+		{"20240108", 5.0, 5.0, 5.0, 5.0, 4.0, 1.0, 1.0},
+	})
+
+	result, err := Resample(df, Options{Rule: mustRule(t, "3D")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Rows) != 2 {
+		t.Fatalf("got %d rows, want 2", len(result.Rows))
+	}
+	for i, want := range []struct {
+		date    string
+		open    float64
+		close   float64
+		vol     float64
+		partial bool
+	}{
+		{"20240104", 1, 3, 3, false},
+		{"20240108", 4, 5, 2, true},
+	} {
+		row := result.Rows[i]
+		if row["trade_date"] != want.date || row["open"] != want.open || row["close"] != want.close ||
+			row["vol"] != want.vol || row["is_partial"] != want.partial {
+			t.Errorf("row %d = %v, want %+v", i, row, want)
+		}
+	}
+
+	// 数据中的日期不在交易日历中
+	if _, err := Resample(df, Options{Rule: mustRule(t, "3D"), TradingDays: []string{"20240102", "20240103"}}); tsError.Cause(err) != tsError.ErrInvalidParameter {
+		t.Errorf("date outside calendar: error = %v, want ErrInvalidParameter", err)
+	}
+}
+
+// minuteBars 按A股交易时段构造一个交易日的1分钟K线，收盘价为K线序号，成交量为1
+func minuteBars(t *testing.T, codes ...string) *types.DataFrame {
+	t.Helper()
+	times, err := session.AShare.Minutes(session.Day{Date: "20240105"}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	df := types.NewDataFrame([]string{"ts_code", "trade_time", "open", "high", "low", "close", "vol"}, nil)
+	for _, code := range codes {
+		for i, tm := range times {
+			v := float64(i)
+			df.Rows = append(df.Rows, map[string]interface{}{
+				"ts_code": code, "trade_time": tm, "open": v, "high": v + 0.5, "low": v - 0.5, "close": v, "vol": 1.0,
+			})
+		}
+	}
+	return df
+}
+
+// TestResampleIntraday 日内周期跳过午休，09:30开盘K线归入第一个周期，以周期结束时间标记
+func TestResampleIntraday(t *testing.T) {
+	df := minuteBars(t, "000001.SZ")
+
+	tests := []struct {
+		rule   string
+		labels []string
+		vols   []float64
+	}{
+		{"60min", []string{"10:30:00", "11:30:00", "14:00:00", "15:00:00"}, []float64{61, 60, 60, 60}},
+		{"90min", []string{"11:00:00", "14:00:00", "15:00:00"}, []float64{91, 90, 60}},
+		{"120min", []string{"11:30:00", "15:00:00"}, []float64{121, 120}},
+	}
+	for _, tt := range tests {
+		result, err := Resample(df, Options{Rule: mustRule(t, tt.rule)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(result.Rows) != len(tt.labels) {
+			t.Errorf("%s: got %d rows, want %d", tt.rule, len(result.Rows), len(tt.labels))
+			continue
+		}
+		for i, row := range result.Rows {
+			if row["trade_time"] != "2024-01-05 "+tt.labels[i] || row["trade_date"] != "20240105" || row["vol"] != tt.vols[i] {
+				t.Errorf("%s row %d = %v %v vol=%v, want %s vol=%v", tt.rule, i, row["trade_time"], row["trade_date"], row["vol"], tt.labels[i], tt.vols[i])
+			}
+		}
+		if last := result.Rows[len(result.Rows)-1]; last["is_partial"] != false {
+			t.Errorf("%s: complete day marked partial", tt.rule)
+		}
+	}
+
+	// 第一个60分钟周期：开盘K线（序号0）至10:30（序号60）
+	result, err := Resample(df, Options{Rule: mustRule(t, "60min")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := result.Rows[0]
+	if first["open"] != 0.0 || first["close"] != 60.0 || first["high"] != 60.5 || first["low"] != -0.5 {
+		t.Errorf("first bar = %v", first)
+	}
+	// 13:01的K线属于14:00周期
+	if third := result.Rows[2]; third["open"] != 121.0 {
+		t.Errorf("14:00 bar open = %v, want 121 (13:01)", third["open"])
+	}
+}
+
+func TestResampleIntradayPartial(t *testing.T) {
+	df := filterRows(minuteBars(t, "000001.SZ", "600000.SH"), func(row map[string]interface{}) bool {
+		return row["trade_time"].(string) <= "2024-01-05 14:10:00"
+	})
+
+	result, err := Resample(df, Options{Rule: mustRule(t, "60min")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 按ts_code分组，每组末尾的15:00周期只到14:10
+	if len(result.Rows) != 8 {
+		t.Fatalf("got %d rows, want 8", len(result.Rows))
+	}
+	for i, row := range result.Rows {
+		code := "000001.SZ"
+		if i >= 4 {
+			code = "600000.SH"
+		}
+		partial := i%4 == 3
+		if row["ts_code"] != code || row["is_partial"] != partial {
+			t.Errorf("row %d = %v %v partial=%v, want %s partial=%v", i, row["ts_code"], row["trade_time"], row["is_partial"], code, partial)
+		}
+	}
+	if last := result.Rows[3]; last["trade_time"] != "2024-01-05 15:00:00" || last["vol"] != 10.0 {
+		t.Errorf("partial bar = %v, want 15:00 with 10 minutes", last)
+	}
+
+	result, err = Resample(df, Options{Rule: mustRule(t, "60min"), Partial: PartialDrop})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Rows) != 6 {
+		t.Errorf("drop partial: got %d rows, want 6", len(result.Rows))
+	}
+
+	if _, err := Resample(df, Options{}); tsError.Cause(err) != tsError.ErrInvalidParameter {
+		t.Errorf("missing rule: error = %v, want ErrInvalidParameter", err)
+	}
+	if _, err := Resample(df, Options{Rule: mustRule(t, "D")}); tsError.Cause(err) != tsError.ErrInvalidParameter {
+		t.Errorf("daily rule on minute data: error = %v, want ErrInvalidParameter", err)
+	}
+}
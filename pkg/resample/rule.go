@@ -0,0 +1,101 @@
+package resample
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	tsError "github.com/Premium-Platform/go-tushare/pkg/errors"
+)
+
+// 重采样周期单位
+const (
+	UnitMinute = "min" // 分钟
+	UnitDay    = "D"   // 交易日
+	UnitWeek   = "W"   // 自然周
+	UnitMonth  = "M"   // 自然月，季度和年按3个月和12个月处理
+)
+
+// Rule 重采样规则，如 120min、3D、2W、Q
+type Rule struct {
+	N    int    // 周期数
+	Unit string // 周期单位
+}
+
+// ParseRule 解析重采样规则
+//
+// 支持的格式：Nmin、Nh（按分钟处理）、ND、NW、NM、NQ、NY，N省略时为1
+func ParseRule(s string) (Rule, error) {
+	s = strings.TrimSpace(s)
+
+	i := 0
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+
+	n := 1
+	if i > 0 {
+		v, err := strconv.Atoi(s[:i])
+		if err != nil || v <= 0 {
+			return Rule{}, tsError.Wrapf(tsError.ErrInvalidParameter, "invalid resample rule %q", s)
+		}
+		n = v
+	}
+
+	switch unit := s[i:]; strings.ToLower(unit) {
+	case "min", "t":
+		return Rule{N: n, Unit: UnitMinute}, nil
+	case "h":
+		return Rule{N: n * 60, Unit: UnitMinute}, nil
+	case "d":
+		return Rule{N: n, Unit: UnitDay}, nil
+	case "w":
+		return Rule{N: n, Unit: UnitWeek}, nil
+	case "m":
+		return Rule{N: n, Unit: UnitMonth}, nil
+	case "q":
+		return Rule{N: n * 3, Unit: UnitMonth}, nil
+	case "y", "a":
+		return Rule{N: n * 12, Unit: UnitMonth}, nil
+	}
+
+	return Rule{}, tsError.Wrapf(tsError.ErrInvalidParameter, "invalid resample rule %q", s)
+}
+
+// Intraday 是否为日内周期
+func (r Rule) Intraday() bool {
+	return r.Unit == UnitMinute
+}
+
+// String 返回规则的字符串形式
+func (r Rule) String() string {
+	return strconv.Itoa(r.N) + r.Unit
+}
+
+// PeriodEnd 获取日期t所在周期的最后一个自然日，anchor为数据的第一个日期，周期对齐方式与Resample相同
+//
+// 交易日周期和日内周期的结束日期取决于交易日历和交易时段，返回t本身
+func (r Rule) PeriodEnd(anchor, t time.Time) time.Time {
+	n := r.N
+	switch r.Unit {
+	case UnitWeek:
+		monday := func(t time.Time) time.Time {
+			return t.AddDate(0, 0, -((int(t.Weekday()) + 6) % 7))
+		}
+		anchorMonday := monday(anchor)
+		weeks := int(monday(t).Sub(anchorMonday).Hours()/24) / 7
+		return anchorMonday.AddDate(0, 0, (weeks/n+1)*n*7-1)
+
+	case UnitMonth:
+		months := func(t time.Time) int {
+			return t.Year()*12 + int(t.Month()) - 1
+		}
+		base := 0
+		if 12%n != 0 {
+			base = months(anchor)
+		}
+		next := ((months(t)-base)/n+1)*n + base
+		return time.Date(next/12, time.Month(next%12+1), 1, 0, 0, 0, 0, t.Location()).AddDate(0, 0, -1)
+	}
+	return t
+}
@@ -0,0 +1,34 @@
+package resample
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPeriodEnd(t *testing.T) {
+	anchor := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC) // 周二
+	tests := []struct {
+		rule string
+		date string
+		want string
+	}{
+		{"W", "20240104", "20240107"},
+		{"2W", "20240110", "20240114"},
+		{"2W", "20240116", "20240128"},
+		{"M", "20240215", "20240229"},
+		{"Q", "20240515", "20240630"},
+		{"5M", "20240615", "20241031"}, // 从首个月份开始计数：1-5月、6-10月
+		{"Y", "20240315", "20241231"},
+		{"3D", "20240105", "20240105"},
+	}
+	for _, tt := range tests {
+		rule, err := ParseRule(tt.rule)
+		if err != nil {
+			t.Fatal(err)
+		}
+		date, _ := time.Parse("20060102", tt.date)
+		if got := rule.PeriodEnd(anchor, date).Format("20060102"); got != tt.want {
+			t.Errorf("%s.PeriodEnd(%s) = %s, want %s", tt.rule, tt.date, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package search
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+	"unicode"
+
+	tsError "github.com/Premium-Platform/go-tushare/pkg/errors"
+)
+
+// MatchKind 匹配方式，数值越大匹配程度越高
+type MatchKind int
+
+const (
+	// MatchFuzzy 查询的字符按顺序出现在目标中，如 pyh 匹配 payh
+	MatchFuzzy MatchKind = iota + 1
+	// MatchContains 目标包含查询
+	MatchContains
+	// MatchPrefix 目标以查询开头
+	MatchPrefix
+	// MatchExact 完全匹配
+	MatchExact
+)
+
+// String 返回匹配方式名称
+func (k MatchKind) String() string {
+	switch k {
+	case MatchExact:
+		return "exact"
+	case MatchPrefix:
+		return "prefix"
+	case MatchContains:
+		return "contains"
+	case MatchFuzzy:
+		return "fuzzy"
+	}
+	return ""
+}
+
+// Entry 可搜索的证券
+type Entry struct {
+	TSCode     string   `json:"ts_code"`     // TS代码，如 000001.SZ
+	Symbol     string   `json:"symbol"`      // 证券代码，如 000001
+	Name       string   `json:"name"`        // 当前名称
+	Cnspell    string   `json:"cnspell"`     // 当前名称的拼音首字母，如 payh
+	ListStatus string   `json:"list_status"` // 上市状态 L上市 D退市 P暂停上市
+	Names      []string `json:"names"`       // 曾用名
+}
+
+// Result 搜索结果
+type Result struct {
+	Entry
+	Kind       MatchKind // 匹配方式
+	Matched    string    // 匹配到的代码、名称或拼音
+	Historical bool      // 是否通过曾用名匹配
+}
+
+// candidate 条目中可匹配的字段
+type candidate struct {
+	text       string // 规范化后的文本
+	original   string // 原始文本
+	historical bool   // 是否为曾用名
+}
+
+// Index 证券搜索索引
+//
+// 按代码、名称、拼音首字母和曾用名匹配，结果按完全匹配、前缀匹配、包含、模糊匹配排序；
+// 同一匹配方式下当前名称优先于曾用名，上市证券优先于退市证券，较短的目标优先。
+type Index struct {
+	entries    []Entry
+	candidates [][]candidate
+}
+
+// NewIndex 创建搜索索引
+func NewIndex(entries []Entry) *Index {
+	ix := &Index{}
+	for _, e := range entries {
+		ix.Add(e)
+	}
+	return ix
+}
+
+// Add 添加证券，曾用名与当前名称相同时忽略
+func (ix *Index) Add(e Entry) {
+	cands := make([]candidate, 0, 4+len(e.Names))
+	for _, s := range []string{e.TSCode, e.Symbol, e.Name, e.Cnspell} {
+		if s != "" {
+			cands = append(cands, candidate{text: normalize(s), original: s})
+		}
+	}
+	for _, name := range e.Names {
+		if name != "" && name != e.Name {
+			cands = append(cands, candidate{text: normalize(name), original: name, historical: true})
+		}
+	}
+	ix.entries = append(ix.entries, e)
+	ix.candidates = append(ix.candidates, cands)
+}
+
+// Len 获取证券数量
+func (ix *Index) Len() int {
+	return len(ix.entries)
+}
+
+// Search 搜索证券，limit<=0时返回全部结果
+//
+// 查询不区分大小写，忽略空白字符；每个证券只返回匹配程度最高的一项
+func (ix *Index) Search(query string, limit int) []Result {
+	q := normalize(query)
+	results := make([]Result, 0)
+	if q == "" {
+		return results
+	}
+
+	for i, e := range ix.entries {
+		var best *Result
+		for _, cand := range ix.candidates[i] {
+			kind := match(cand.text, q)
+			if kind == 0 {
+				continue
+			}
+			r := Result{Entry: e, Kind: kind, Matched: cand.original, Historical: cand.historical}
+			if best == nil || better(r, *best) {
+				best = &r
+			}
+		}
+		if best != nil {
+			results = append(results, *best)
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return better(results[i], results[j])
+	})
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// Save 以JSON格式保存索引中的证券，可用Load在本地恢复
+func (ix *Index) Save(w io.Writer) error {
+	if err := json.NewEncoder(w).Encode(ix.entries); err != nil {
+		return tsError.Wrap(err, "failed to save search index")
+	}
+	return nil
+}
+
+// Load 读取Save保存的索引
+func Load(r io.Reader) (*Index, error) {
+	var entries []Entry
+	if err := json.NewDecoder(r).Decode(&entries); err != nil {
+		return nil, tsError.Wrap(err, "failed to load search index")
+	}
+	return NewIndex(entries), nil
+}
+
+// better 判断结果a是否排在b之前
+func better(a, b Result) bool {
+	if a.Kind != b.Kind {
+		return a.Kind > b.Kind
+	}
+	if a.Historical != b.Historical {
+		return !a.Historical
+	}
+	if listedA, listedB := a.ListStatus != "D", b.ListStatus != "D"; listedA != listedB {
+		return listedA
+	}
+	if la, lb := len([]rune(a.Matched)), len([]rune(b.Matched)); la != lb {
+		return la < lb
+	}
+	return a.TSCode < b.TSCode
+}
+
+// match 判断文本与查询的匹配方式，不匹配时返回0
+func match(text, query string) MatchKind {
+	switch {
+	case text == query:
+		return MatchExact
+	case strings.HasPrefix(text, query):
+		return MatchPrefix
+	case strings.Contains(text, query):
+		return MatchContains
+	case isSubsequence(text, query):
+		return MatchFuzzy
+	}
+	return 0
+}
+
+// isSubsequence 查询的字符是否按顺序全部出现在文本中
+func isSubsequence(text, query string) bool {
+	q := []rune(query)
+	i := 0
+	for _, r := range text {
+		if i < len(q) && r == q[i] {
+			i++
+		}
+	}
+	return i == len(q)
+}
+
+// normalize 转换为小写，全角字母数字转换为半角，并去除空白字符
+func normalize(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if unicode.IsSpace(r) {
+			continue
+		}
+		if r >= '！' && r <= '～' {
+			r -= '！' - '!'
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
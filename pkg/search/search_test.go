@@ -0,0 +1,157 @@
+package search
+
+import (
+	"bytes"
+	"testing"
+)
+
+// testEntries 测试用证券：平安银行曾用名深发展A，退市的同名证券用于检查上市状态排序
+var testEntries = []Entry{
+	{TSCode: "000001.SZ", Symbol: "000001", Name: "平安银行", Cnspell: "payh", ListStatus: "L", Names: []string{"深发展A", "S深发展A", "平安银行"}},
+	{TSCode: "601318.SH", Symbol: "601318", Name: "中国平安", Cnspell: "zgpa", ListStatus: "L"},
+	{TSCode: "600000.SH", Symbol: "600000", Name: "浦发银行", Cnspell: "pfyh", ListStatus: "L"},
+	{TSCode: "000002.SZ", Symbol: "000002", Name: "万科A", Cnspell: "wka", ListStatus: "L"},
+	{TSCode: "000003.SZ", Symbol: "000003", Name: "PT金田A", Cnspell: "ptjta", ListStatus: "D", Names: []string{"金田实业"}},
+	{TSCode: "600003.SH", Symbol: "600003", Name: "ST东北高", Cnspell: "stdbg", ListStatus: "D"},
+	{TSCode: "600004.SH", Symbol: "600004", Name: "白云机场", Cnspell: "byjc", ListStatus: "L"},
+}
+
+// resultCodes 搜索结果的代码和匹配方式
+func resultCodes(results []Result) []string {
+	codes := make([]string, 0, len(results))
+	for _, r := range results {
+		codes = append(codes, r.TSCode+":"+r.Kind.String())
+	}
+	return codes
+}
+
+func checkResults(t *testing.T, query string, got []Result, want ...string) {
+	t.Helper()
+	codes := resultCodes(got)
+	if len(codes) != len(want) {
+		t.Errorf("Search(%q) = %v, want %v", query, codes, want)
+		return
+	}
+	for i := range want {
+		if codes[i] != want[i] {
+			t.Errorf("Search(%q) = %v, want %v", query, codes, want)
+			return
+		}
+	}
+}
+
+// TestSearchRanking 完全匹配 > 前缀匹配 > 包含 > 模糊匹配
+func TestSearchRanking(t *testing.T) {
+	ix := NewIndex(testEntries)
+	if ix.Len() != len(testEntries) {
+		t.Fatalf("Len = %d, want %d", ix.Len(), len(testEntries))
+	}
+
+	// 000001完全匹配代码；00000前缀匹配000001至000003（退市的000003在后），包含匹配600000
+	checkResults(t, "000001", ix.Search("000001", 0),
+		"000001.SZ:exact")
+	checkResults(t, "00000", ix.Search("00000", 0),
+		"000001.SZ:prefix", "000002.SZ:prefix", "000003.SZ:prefix", "600000.SH:contains")
+
+	// 平安：前缀匹配平安银行，包含匹配中国平安
+	checkResults(t, "平安", ix.Search("平安", 0), "000001.SZ:prefix", "601318.SH:contains")
+
+	// 拼音首字母：pa前缀匹配payh，包含匹配zgpa，模糊匹配ptjta；pyh模糊匹配payh、pfyh
+	checkResults(t, "pa", ix.Search("pa", 0), "000001.SZ:prefix", "601318.SH:contains", "000003.SZ:fuzzy")
+	checkResults(t, "pyh", ix.Search("pyh", 0), "000001.SZ:fuzzy", "600000.SH:fuzzy")
+
+	// 每个证券只返回匹配程度最高的一项
+	results := ix.Search("payh", 0)
+	checkResults(t, "payh", results, "000001.SZ:exact")
+	if results[0].Matched != "payh" {
+		t.Errorf("Matched = %s, want payh", results[0].Matched)
+	}
+
+	if results := ix.Search("00000", 2); len(results) != 2 {
+		t.Errorf("limit 2: got %d results", len(results))
+	}
+	if results := ix.Search("  ", 0); len(results) != 0 {
+		t.Errorf("blank query: got %v", resultCodes(results))
+	}
+	if results := ix.Search("不存在", 0); len(results) != 0 {
+		t.Errorf("no match: got %v", resultCodes(results))
+	}
+}
+
+// TestSearchListedFirst 同一匹配方式下上市证券优先于退市证券
+func TestSearchListedFirst(t *testing.T) {
+	ix := NewIndex([]Entry{
+		{TSCode: "600001.SH", Name: "邯郸钢铁", Cnspell: "hdgt", ListStatus: "D"},
+		{TSCode: "600002.SH", Name: "齐鲁石化", Cnspell: "qlsh", ListStatus: "D"},
+		{TSCode: "600010.SH", Name: "包钢股份", Cnspell: "bggf", ListStatus: "L"},
+		{TSCode: "600019.SH", Name: "宝钢股份", Cnspell: "bggf", ListStatus: "P"},
+	})
+
+	// 600010、600019虽然代码排在后面，仍排在退市的600001之前；暂停上市不视为退市
+	checkResults(t, "6000", ix.Search("6000", 0),
+		"600010.SH:prefix", "600019.SH:prefix", "600001.SH:prefix", "600002.SH:prefix")
+	// 匹配方式优先于上市状态
+	checkResults(t, "邯郸钢铁", ix.Search("邯郸钢铁", 0), "600001.SH:exact")
+	// 上市状态相同时按代码排序
+	checkResults(t, "bggf", ix.Search("bggf", 0), "600010.SH:exact", "600019.SH:exact")
+}
+
+// TestSearchHistoricalNames 曾用名可以被搜索到，并排在当前名称的同等匹配之后
+func TestSearchHistoricalNames(t *testing.T) {
+	ix := NewIndex(append(testEntries, Entry{TSCode: "000999.SZ", Name: "深发展科技", ListStatus: "L"}))
+
+	results := ix.Search("深发展", 0)
+	checkResults(t, "深发展", results, "000999.SZ:prefix", "000001.SZ:prefix")
+	if r := results[1]; !r.Historical || r.Matched != "深发展A" || r.Name != "平安银行" {
+		t.Errorf("historical hit = %+v, want 深发展A of 平安银行", r)
+	}
+	if results[0].Historical {
+		t.Error("current name hit marked historical")
+	}
+
+	// 曾用名与当前名称相同时视为当前名称
+	results = ix.Search("平安银行", 0)
+	checkResults(t, "平安银行", results, "000001.SZ:exact")
+	if results[0].Historical {
+		t.Error("current name hit marked historical")
+	}
+
+	// 曾用名前缀匹配优先于当前名称的包含匹配
+	results = ix.Search("金田", 0)
+	checkResults(t, "金田", results, "000003.SZ:prefix")
+	if !results[0].Historical || results[0].Matched != "金田实业" {
+		t.Errorf("金田 = %+v, want historical hit 金田实业", results[0])
+	}
+}
+
+// TestSearchNormalize 查询不区分大小写，忽略空白，全角字母数字按半角处理
+func TestSearchNormalize(t *testing.T) {
+	ix := NewIndex(testEntries)
+	for _, query := range []string{"PAYH", " pa yh ", "ｐａｙｈ", "０００００１"} {
+		if results := ix.Search(query, 1); len(results) != 1 || results[0].TSCode != "000001.SZ" {
+			t.Errorf("Search(%q) = %v, want 000001.SZ", query, resultCodes(results))
+		}
+	}
+	checkResults(t, "万科a", ix.Search("万科a", 0), "000002.SZ:exact")
+}
+
+func TestIndexSaveLoad(t *testing.T) {
+	ix := NewIndex(testEntries)
+
+	var buf bytes.Buffer
+	if err := ix.Save(&buf); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := Load(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Len() != ix.Len() {
+		t.Errorf("loaded %d entries, want %d", loaded.Len(), ix.Len())
+	}
+	checkResults(t, "深发展", loaded.Search("深发展", 0), "000001.SZ:prefix")
+
+	if _, err := Load(bytes.NewBufferString("not json")); err == nil {
+		t.Error("Load invalid data: want error")
+	}
+}
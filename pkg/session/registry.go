@@ -0,0 +1,124 @@
+package session
+
+import (
+	"strings"
+
+	tsError "github.com/Premium-Platform/go-tushare/pkg/errors"
+)
+
+// AShare A股交易时段（沪深北交易所）
+//
+// 连续竞价09:30-11:30、13:00-15:00（收盘集合竞价14:57-15:00计入下午时段），开盘集合竞价09:15-09:25
+var AShare = &Session{
+	Name:     "A股",
+	Periods:  []Period{{Start: "09:30", End: "11:30"}, {Start: "13:00", End: "15:00"}},
+	Auctions: []Period{{Start: "09:15", End: "09:25"}, {Start: "14:57", End: "15:00"}},
+	OpenBar:  true,
+}
+
+// CommodityFuture 商品期货日盘交易时段
+var CommodityFuture = &Session{
+	Name:     "商品期货",
+	Periods:  []Period{{Start: "09:00", End: "10:15"}, {Start: "10:30", End: "11:30"}, {Start: "13:30", End: "15:00"}},
+	Auctions: []Period{{Start: "08:55", End: "09:00"}},
+}
+
+// IndexFuture 股指期货交易时段
+var IndexFuture = &Session{
+	Name:     "股指期货",
+	Periods:  []Period{{Start: "09:30", End: "11:30"}, {Start: "13:00", End: "15:00"}},
+	Auctions: []Period{{Start: "09:25", End: "09:30"}},
+}
+
+// BondFuture 国债期货交易时段
+var BondFuture = &Session{
+	Name:     "国债期货",
+	Periods:  []Period{{Start: "09:30", End: "11:30"}, {Start: "13:00", End: "15:15"}},
+	Auctions: []Period{{Start: "09:25", End: "09:30"}},
+}
+
+// exchangeAlias 交易所代码及TuShare代码后缀的对应关系
+var exchangeAlias = map[string]string{
+	"SSE": "SSE", "SH": "SSE",
+	"SZSE": "SZSE", "SZ": "SZSE",
+	"BSE": "BSE", "BJ": "BSE",
+	"SHFE": "SHFE", "SHF": "SHFE",
+	"DCE":  "DCE",
+	"CZCE": "CZCE", "ZCE": "CZCE",
+	"CFFEX": "CFFEX", "CFX": "CFFEX",
+	"INE":  "INE",
+	"GFEX": "GFEX", "GFE": "GFEX",
+}
+
+// nightPeriods 期货品种的夜盘时段
+var nightPeriods = map[string]Period{}
+
+// bondFutures 中金所国债期货品种
+var bondFutures = []string{"T", "TF", "TS", "TL"}
+
+func init() {
+	register := func(exchange string, end string, products ...string) {
+		for _, p := range products {
+			nightPeriods[exchange+"/"+p] = Period{Start: "21:00", End: end}
+		}
+	}
+
+	register("SHFE", "02:30", "AU", "AG")
+	register("SHFE", "01:00", "CU", "AL", "ZN", "PB", "NI", "SN", "SS", "AO")
+	register("SHFE", "23:00", "RB", "HC", "FU", "BU", "RU", "SP", "BR")
+	register("INE", "02:30", "SC")
+	register("INE", "01:00", "BC")
+	register("INE", "23:00", "LU", "NR")
+	register("DCE", "23:00", "A", "B", "M", "Y", "P", "C", "CS", "RR", "I", "J", "JM", "L", "V", "PP", "EB", "EG", "PG")
+	register("CZCE", "23:00", "SR", "CF", "CY", "TA", "MA", "FG", "RM", "OI", "ZC", "SA", "PF", "PX", "SH")
+}
+
+// Lookup 获取交易所（及期货品种）的交易时段
+//
+// exchange可使用交易所代码（SSE、SHFE等）或TuShare代码后缀（SH、SHF等）；期货需要传入品种代码（如RB、au），
+// 有夜盘的品种在日盘时段前加上夜盘时段。中金所按品种区分股指期货和国债期货，广期所没有夜盘。
+func Lookup(exchange, product string) (*Session, error) {
+	ex, ok := exchangeAlias[strings.ToUpper(exchange)]
+	if !ok {
+		return nil, tsError.Wrapf(tsError.ErrInvalidParameter, "unknown exchange %s", exchange)
+	}
+	product = strings.ToUpper(product)
+
+	switch ex {
+	case "SSE", "SZSE", "BSE":
+		return AShare, nil
+	case "CFFEX":
+		for _, p := range bondFutures {
+			if p == product {
+				return BondFuture, nil
+			}
+		}
+		return IndexFuture, nil
+	}
+
+	night, ok := nightPeriods[ex+"/"+product]
+	if !ok {
+		return CommodityFuture, nil
+	}
+	return &Session{
+		Name:     CommodityFuture.Name + " " + product,
+		Periods:  append([]Period{night}, CommodityFuture.Periods...),
+		Auctions: append([]Period{{Start: "20:55", End: "21:00"}}, CommodityFuture.Auctions...),
+	}, nil
+}
+
+// ForCode 根据TuShare证券代码获取交易时段，如 000001.SZ、RB2405.SHF、IF.CFX
+func ForCode(tsCode string) (*Session, error) {
+	idx := strings.LastIndex(tsCode, ".")
+	if idx < 0 {
+		return nil, tsError.Wrapf(tsError.ErrInvalidParameter, "invalid ts_code %s", tsCode)
+	}
+
+	// 期货代码取开头的字母作为品种代码
+	symbol := tsCode[:idx]
+	end := 0
+	for end < len(symbol) && (symbol[end] < '0' || symbol[end] > '9') {
+		end++
+	}
+	return Lookup(tsCode[idx+1:], symbol[:end])
+}
@@ -0,0 +1,297 @@
+package session
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Premium-Platform/go-tushare/pkg/calendar"
+	tsError "github.com/Premium-Platform/go-tushare/pkg/errors"
+	"github.com/Premium-Platform/go-tushare/pkg/types"
+)
+
+// nightStart 晚于该时刻（当日分钟数）开始的时段视为夜盘
+const nightStart = 18 * 60
+
+// minutesPerDay 一天的分钟数
+const minutesPerDay = 24 * 60
+
+// timeLayout 分钟K线的时间格式
+const timeLayout = "2006-01-02 15:04:05"
+
+// Period 交易时段（HH:MM），夜盘时段的结束时间早于开始时间时表示跨越午夜
+type Period struct {
+	Start string
+	End   string
+}
+
+// Session 日内交易时段
+type Session struct {
+	Name     string   // 名称
+	Periods  []Period // 连续交易时段，按交易日内的顺序排列（夜盘在前）
+	Auctions []Period // 集合竞价时段
+	OpenBar  bool     // 1分钟K线是否包含以开盘时间标记的开盘集合竞价K线（如A股09:30）
+}
+
+// Day 某个交易日的交易安排
+type Day struct {
+	Date      string // 交易日期 YYYYMMDD
+	NightDate string // 夜盘所在的自然日（通常为上一交易日），为空时当日没有夜盘
+}
+
+// parseClock 解析HH:MM为当日分钟数
+func parseClock(s string) int {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0
+	}
+	return t.Hour()*60 + t.Minute()
+}
+
+// normalize 将当日分钟数转换为交易日内的分钟数：夜盘开始后的时刻记为负数，使夜盘排在日盘之前
+func normalize(clock int) int {
+	if clock >= nightStart {
+		return clock - minutesPerDay
+	}
+	return clock
+}
+
+// bounds 获取时段在交易日内的起止分钟数
+func (p Period) bounds() (int, int) {
+	return normalize(parseClock(p.Start)), normalize(parseClock(p.End))
+}
+
+// Night 是否为夜盘时段
+func (p Period) Night() bool {
+	return parseClock(p.Start) >= nightStart
+}
+
+// HasNight 是否有夜盘
+func (s *Session) HasNight() bool {
+	for _, p := range s.Periods {
+		if p.Night() {
+			return true
+		}
+	}
+	return false
+}
+
+// Length 连续交易时段总分钟数
+func (s *Session) Length() int {
+	return periodsLength(s.Periods)
+}
+
+// Elapsed 计算某一时刻（当日分钟数）在连续交易时段内已经过的分钟数
+func (s *Session) Elapsed(clock int) int {
+	return periodsElapsed(s.Periods, normalize(clock))
+}
+
+// Clock 将连续交易时段内已经过的分钟数转换为时刻（当日分钟数）
+func (s *Session) Clock(elapsed int) int {
+	_, clock := locate(s.Periods, elapsed)
+	return (clock + minutesPerDay) % minutesPerDay
+}
+
+// IsOpen 判断某一时刻是否处于连续交易或集合竞价时段（不考虑交易日历）
+func (s *Session) IsOpen(t time.Time) bool {
+	clock := normalize(t.Hour()*60 + t.Minute())
+	for _, periods := range [][]Period{s.Periods, s.Auctions} {
+		for _, p := range periods {
+			start, end := p.bounds()
+			if clock >= start && clock < end {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// periods 获取交易日实际的连续交易时段：没有夜盘时去掉夜盘时段
+func (s *Session) periods(day Day) []Period {
+	result := make([]Period, 0, len(s.Periods))
+	for _, p := range s.Periods {
+		if p.Night() && day.NightDate == "" {
+			continue
+		}
+		result = append(result, p)
+	}
+	return result
+}
+
+// Minutes 列出交易日应有的分钟K线时间（YYYY-MM-DD HH:MM:SS，升序）
+//
+// K线以结束时间标记，周期在连续交易时段内按分钟数切分并跳过休市时间（与本地重采样一致），
+// 最后一个周期不足freq分钟时以收市时间标记。夜盘跨越午夜后的K线日期为夜盘次日。
+func (s *Session) Minutes(day Day, freq int) ([]string, error) {
+	if freq <= 0 {
+		return nil, tsError.Wrapf(tsError.ErrInvalidParameter, "invalid minute frequency %d", freq)
+	}
+	date, err := time.Parse("20060102", day.Date)
+	if err != nil {
+		return nil, tsError.Wrapf(tsError.ErrInvalidParameter, "invalid date %q", day.Date)
+	}
+	var night time.Time
+	if day.NightDate != "" {
+		if night, err = time.Parse("20060102", day.NightDate); err != nil {
+			return nil, tsError.Wrapf(tsError.ErrInvalidParameter, "invalid night date %q", day.NightDate)
+		}
+	}
+
+	periods := s.periods(day)
+	total := periodsLength(periods)
+
+	// 时段内的时刻转换为时间
+	stamp := func(idx, clock int) string {
+		base := date
+		if periods[idx].Night() {
+			base = night
+			if clock >= 0 {
+				base = night.AddDate(0, 0, 1)
+			}
+		}
+		clock = (clock + minutesPerDay) % minutesPerDay
+		return base.Add(time.Duration(clock) * time.Minute).Format(timeLayout)
+	}
+
+	result := make([]string, 0, total/freq+2)
+	if s.OpenBar && freq == 1 {
+		for i, p := range periods {
+			if !p.Night() {
+				start, _ := p.bounds()
+				result = append(result, stamp(i, start))
+				break
+			}
+		}
+	}
+	for elapsed := freq; ; elapsed += freq {
+		if elapsed > total {
+			elapsed = total
+		}
+		if elapsed <= 0 {
+			break
+		}
+		idx, clock := locate(periods, elapsed)
+		result = append(result, stamp(idx, clock))
+		if elapsed == total {
+			break
+		}
+	}
+	return result, nil
+}
+
+// MissingMinutes 检查分钟K线数据缺失的时间
+//
+// df需包含trade_time列。days为空时按数据中出现的交易日检查（使用trade_date列，没有时取trade_time的日期），
+// 此时不检查夜盘；需要检查整日缺失或夜盘时传入完整的交易日安排（见TradingDay）。
+func (s *Session) MissingMinutes(df *types.DataFrame, freq int, days ...Day) ([]string, error) {
+	if df == nil {
+		return nil, nil
+	}
+
+	present := make(map[string]bool, len(df.Rows))
+	dates := make(map[string]bool)
+	for _, row := range df.Rows {
+		t, _ := row["trade_time"].(string)
+		if t == "" {
+			continue
+		}
+		present[t] = true
+		date, _ := row["trade_date"].(string)
+		if date == "" && len(t) >= 10 {
+			date = strings.ReplaceAll(t[:10], "-", "")
+		}
+		dates[date] = true
+	}
+
+	if len(days) == 0 {
+		for date := range dates {
+			days = append(days, Day{Date: date})
+		}
+	}
+
+	missing := make([]string, 0)
+	for _, day := range days {
+		expected, err := s.Minutes(day, freq)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range expected {
+			if !present[t] {
+				missing = append(missing, t)
+			}
+		}
+	}
+	sort.Strings(missing)
+	return missing, nil
+}
+
+// TradingDay 根据交易日历确定交易日的交易安排
+//
+// 夜盘属于下一个交易日：上一交易日晚间有夜盘，除非两个交易日之间有节假日（长假前最后一个交易日没有夜盘），
+// 周末不影响（周五夜盘属于下周一）。
+func TradingDay(cal *calendar.Calendar, date string) (Day, error) {
+	prev, err := cal.PrevTradingDay(date)
+	if err != nil {
+		return Day{}, err
+	}
+
+	day := Day{Date: date}
+	if hasNight(prev, date) {
+		day.NightDate = prev
+	}
+	return day, nil
+}
+
+// hasNight 判断两个相邻交易日之间是否只隔周末
+func hasNight(prev, date string) bool {
+	start, err1 := time.Parse("20060102", prev)
+	end, err2 := time.Parse("20060102", date)
+	if err1 != nil || err2 != nil {
+		return false
+	}
+	for d := start.AddDate(0, 0, 1); d.Before(end); d = d.AddDate(0, 0, 1) {
+		if d.Weekday() != time.Saturday && d.Weekday() != time.Sunday {
+			return false
+		}
+	}
+	return true
+}
+
+// periodsLength 时段总分钟数
+func periodsLength(periods []Period) int {
+	total := 0
+	for _, p := range periods {
+		start, end := p.bounds()
+		total += end - start
+	}
+	return total
+}
+
+// periodsElapsed 计算交易日内的某一时刻在时段内已经过的分钟数
+func periodsElapsed(periods []Period, clock int) int {
+	elapsed := 0
+	for _, p := range periods {
+		start, end := p.bounds()
+		switch {
+		case clock >= end:
+			elapsed += end - start
+		case clock > start:
+			elapsed += clock - start
+		}
+	}
+	return elapsed
+}
+
+// locate 将时段内已经过的分钟数转换为所在时段的序号和交易日内的时刻
+func locate(periods []Period, elapsed int) (int, int) {
+	idx, clock := 0, 0
+	for i, p := range periods {
+		start, end := p.bounds()
+		idx, clock = i, end
+		if elapsed <= end-start {
+			return i, start + elapsed
+		}
+		elapsed -= end - start
+	}
+	return idx, clock
+}
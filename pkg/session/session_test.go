@@ -0,0 +1,216 @@
+package session
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Premium-Platform/go-tushare/pkg/calendar"
+	tsError "github.com/Premium-Platform/go-tushare/pkg/errors"
+	"github.com/Premium-Platform/go-tushare/pkg/types"
+)
+
+// TestAShareMinutes A股1分钟K线含09:30开盘集合竞价K线共241根，午休时间不产生K线
+func TestAShareMinutes(t *testing.T) {
+	minutes, err := AShare.Minutes(Day{Date: "20240105"}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(minutes) != 241 {
+		t.Fatalf("got %d bars, want 241", len(minutes))
+	}
+	for i, want := range map[int]string{
+		0:   "2024-01-05 09:30:00",
+		1:   "2024-01-05 09:31:00",
+		120: "2024-01-05 11:30:00",
+		121: "2024-01-05 13:01:00",
+		240: "2024-01-05 15:00:00",
+	} {
+		if minutes[i] != want {
+			t.Errorf("bar %d = %s, want %s", i, minutes[i], want)
+		}
+	}
+
+	// 5分钟K线不含开盘K线
+	minutes, err = AShare.Minutes(Day{Date: "20240105"}, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(minutes) != 48 || minutes[0] != "2024-01-05 09:35:00" || minutes[24] != "2024-01-05 13:05:00" {
+		t.Errorf("5min bars = %d, first %s, 25th %s", len(minutes), minutes[0], minutes[24])
+	}
+
+	// 不能整除时最后一个周期以收市时间标记
+	minutes, err = AShare.Minutes(Day{Date: "20240105"}, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if last := minutes[len(minutes)-1]; len(minutes) != 35 || last != "2024-01-05 15:00:00" {
+		t.Errorf("7min bars = %d, last %s, want 35 ending at 15:00", len(minutes), last)
+	}
+
+	for _, day := range []Day{{Date: "2024-01-05"}, {Date: "20240108", NightDate: "bad"}} {
+		if _, err := AShare.Minutes(day, 1); tsError.Cause(err) != tsError.ErrInvalidParameter {
+			t.Errorf("Minutes(%+v) error = %v, want ErrInvalidParameter", day, err)
+		}
+	}
+	if _, err := AShare.Minutes(Day{Date: "20240105"}, 0); tsError.Cause(err) != tsError.ErrInvalidParameter {
+		t.Errorf("Minutes freq 0: error = %v, want ErrInvalidParameter", err)
+	}
+}
+
+// TestNightMinutes 夜盘K线在日盘之前，跨越午夜后的K线日期为夜盘次日
+func TestNightMinutes(t *testing.T) {
+	gold, err := Lookup("SHFE", "au")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 周一的夜盘在上周五晚间
+	day := Day{Date: "20240108", NightDate: "20240105"}
+	minutes, err := gold.Minutes(day, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 夜盘21:00-02:30共330分钟，日盘225分钟
+	if len(minutes) != 330+225 {
+		t.Fatalf("got %d bars, want %d", len(minutes), 330+225)
+	}
+	for i, want := range map[int]string{
+		0:   "2024-01-05 21:01:00",
+		178: "2024-01-05 23:59:00",
+		179: "2024-01-06 00:00:00",
+		180: "2024-01-06 00:01:00",
+		329: "2024-01-06 02:30:00",
+		330: "2024-01-08 09:01:00",
+		404: "2024-01-08 10:15:00",
+		405: "2024-01-08 10:31:00",
+		554: "2024-01-08 15:00:00",
+	} {
+		if minutes[i] != want {
+			t.Errorf("bar %d = %s, want %s", i, minutes[i], want)
+		}
+	}
+
+	// 夜盘当日结束的品种不跨越午夜
+	rebar, err := ForCode("RB2405.SHF")
+	if err != nil {
+		t.Fatal(err)
+	}
+	minutes, err = rebar.Minutes(day, 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if minutes[0] != "2024-01-05 21:30:00" || minutes[3] != "2024-01-05 23:00:00" || minutes[4] != "2024-01-08 09:30:00" {
+		t.Errorf("rebar 30min bars = %v", minutes)
+	}
+
+	// 没有夜盘的交易日只有日盘
+	minutes, err = gold.Minutes(Day{Date: "20240219"}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(minutes) != 225 || minutes[0] != "2024-02-19 09:01:00" {
+		t.Errorf("got %d bars starting %s, want 225 starting 09:01", len(minutes), minutes[0])
+	}
+}
+
+// TestTradingDay 夜盘属于下一个交易日，周末不影响，长假前最后一个交易日没有夜盘
+func TestTradingDay(t *testing.T) {
+	cal, err := calendar.NewSnapshot("SHFE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		date  string
+		night string
+	}{
+		{"20240104", "20240103"},
+		{"20240108", "20240105"}, // 周一的夜盘来自周五
+		{"20240219", ""},         // 春节后首个交易日
+	}
+	for _, tt := range tests {
+		day, err := TradingDay(cal, tt.date)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if day.Date != tt.date || day.NightDate != tt.night {
+			t.Errorf("TradingDay(%s) = %+v, want night date %q", tt.date, day, tt.night)
+		}
+	}
+}
+
+// minuteFrame 由K线时间构造分钟线数据
+func minuteFrame(times []string) *types.DataFrame {
+	rows := make([]map[string]interface{}, 0, len(times))
+	for _, t := range times {
+		rows = append(rows, map[string]interface{}{"trade_time": t})
+	}
+	return types.NewDataFrame([]string{"trade_time"}, rows)
+}
+
+func TestMissingMinutes(t *testing.T) {
+	minutes, err := AShare.Minutes(Day{Date: "20240105"}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	times := make([]string, 0, len(minutes))
+	for _, m := range minutes {
+		if m != "2024-01-05 10:00:00" && m != "2024-01-05 13:01:00" {
+			times = append(times, m)
+		}
+	}
+	df := minuteFrame(times)
+
+	// 未传入交易日时按数据中出现的交易日检查
+	missing, err := AShare.MissingMinutes(df, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(missing, ",") != "2024-01-05 10:00:00,2024-01-05 13:01:00" {
+		t.Errorf("missing = %v, want 10:00 and 13:01", missing)
+	}
+
+	// 传入交易日时整日缺失的交易日也被列出
+	missing, err = AShare.MissingMinutes(df, 1, Day{Date: "20240104"}, Day{Date: "20240105"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(missing) != 241+2 || missing[0] != "2024-01-04 09:30:00" {
+		t.Errorf("got %d missing starting %v, want 243 starting 2024-01-04 09:30:00", len(missing), missing[0])
+	}
+
+	if missing, err := AShare.MissingMinutes(minuteFrame(minutes), 1); err != nil || len(missing) != 0 {
+		t.Errorf("complete data: missing = %v, %v", missing, err)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		code  string
+		name  string
+		night bool
+	}{
+		{"000001.SZ", AShare.Name, false},
+		{"830799.BJ", AShare.Name, false},
+		{"IF2403.CFX", IndexFuture.Name, false},
+		{"T2403.CFX", BondFuture.Name, false},
+		{"LH2405.DCE", CommodityFuture.Name, false},
+		{"SI2405.GFE", CommodityFuture.Name, false},
+		{"M2405.DCE", CommodityFuture.Name + " M", true},
+		{"SC2405.INE", CommodityFuture.Name + " SC", true},
+	}
+	for _, tt := range tests {
+		sess, err := ForCode(tt.code)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sess.Name != tt.name || sess.HasNight() != tt.night {
+			t.Errorf("ForCode(%s) = %s night=%v, want %s night=%v", tt.code, sess.Name, sess.HasNight(), tt.name, tt.night)
+		}
+	}
+
+	for _, code := range []string{"000001", "RB2405.XYZ"} {
+		if _, err := ForCode(code); tsError.Cause(err) != tsError.ErrInvalidParameter {
+			t.Errorf("ForCode(%s) error = %v, want ErrInvalidParameter", code, err)
+		}
+	}
+}
@@ -0,0 +1,276 @@
+package symbol
+
+import (
+	"strings"
+
+	tsError "github.com/Premium-Platform/go-tushare/pkg/errors"
+)
+
+// 沪深北交易所的TuShare代码后缀
+const (
+	SH = "SH" // 上海证券交易所
+	SZ = "SZ" // 深圳证券交易所
+	BJ = "BJ" // 北京证券交易所
+)
+
+// 股票所属板块
+const (
+	BoardMain    = "main"    // 主板（含原中小板、B股）
+	BoardSTAR    = "star"    // 科创板
+	BoardChiNext = "chinext" // 创业板
+	BoardBSE     = "bse"     // 北交所
+)
+
+// Format 证券代码格式
+type Format string
+
+// 支持的证券代码格式，以浦发银行为例
+const (
+	FormatTuShare   Format = "tushare"   // 600000.SH（与Wind相同）
+	FormatJoinQuant Format = "joinquant" // 600000.XSHG（聚宽、米筐）
+	FormatPrefix    Format = "prefix"    // sh600000（新浪、腾讯、东方财富）
+	FormatBaostock  Format = "baostock"  // sh.600000
+	FormatYahoo     Format = "yahoo"     // 600000.SS
+)
+
+// vendorSuffix 其他格式的交易所后缀与TuShare后缀的对应关系
+var vendorSuffix = map[string]string{
+	"XSHG": SH, "SS": SH,
+	"XSHE": SZ,
+	"BJSE": BJ,
+}
+
+// Symbol 解析后的证券代码
+type Symbol struct {
+	Code     string // 代码主体，如 600000、RB2405
+	Exchange string // TuShare代码后缀，如 SH、SZ、BJ、SHF、HK
+	Board    string // 所属板块，仅沪深北股票有值
+}
+
+// Parse 解析证券代码
+//
+// 支持TuShare（600000.SH）、聚宽（600000.XSHG）、前缀（sh600000）、Baostock（sh.600000）、
+// Yahoo（600000.SS）格式，不区分大小写；6位纯数字代码按代码前缀推断交易所，
+// 推断规则面向股票、场内基金和可转债，指数代码（如000300）需要带后缀。
+// 期货、期权、港股等其他后缀的代码只检查字符，原样保留（转换为大写），代码主体可包含连字符
+// （如中金所、商品期权IO2106-C-5000.CFX）；美股等不带后缀的字母代码原样保留。
+func Parse(s string) (Symbol, error) {
+	code := strings.ToUpper(strings.TrimSpace(s))
+	if code == "" {
+		return Symbol{}, tsError.Wrap(tsError.ErrInvalidParameter, "empty ts_code")
+	}
+	for _, r := range code {
+		if !(r >= '0' && r <= '9' || r >= 'A' && r <= 'Z' || r == '.' || r == '-') {
+			return Symbol{}, tsError.Wrapf(tsError.ErrInvalidParameter, "invalid ts_code %q", s)
+		}
+	}
+	if strings.HasPrefix(code, "-") || strings.HasSuffix(code, "-") || strings.Contains(code, "-.") || strings.Contains(code, ".-") {
+		return Symbol{}, tsError.Wrapf(tsError.ErrInvalidParameter, "invalid ts_code %q", s)
+	}
+
+	parts := strings.Split(code, ".")
+	switch len(parts) {
+	case 1:
+		return parseBare(s, code)
+	case 2:
+	default:
+		return Symbol{}, tsError.Wrapf(tsError.ErrInvalidParameter, "invalid ts_code %q", s)
+	}
+
+	body, suffix := parts[0], parts[1]
+	if body == "" || suffix == "" {
+		return Symbol{}, tsError.Wrapf(tsError.ErrInvalidParameter, "invalid ts_code %q", s)
+	}
+
+	// Baostock格式：交易所在前
+	if isStockExchange(body) && isDigits(suffix) {
+		return newStock(s, suffix, body)
+	}
+
+	if ex, ok := vendorSuffix[suffix]; ok {
+		suffix = ex
+	}
+	if isStockExchange(suffix) {
+		return newStock(s, body, suffix)
+	}
+	if !isLetters(suffix) {
+		return Symbol{}, tsError.Wrapf(tsError.ErrInvalidParameter, "invalid exchange suffix in ts_code %q", s)
+	}
+	return Symbol{Code: body, Exchange: suffix}, nil
+}
+
+// parseBare 解析不带后缀的代码
+func parseBare(s, code string) (Symbol, error) {
+	// 前缀格式：sh600000
+	if len(code) == 8 && isStockExchange(code[:2]) && isDigits(code[2:]) {
+		return newStock(s, code[2:], code[:2])
+	}
+	if !isDigits(code) {
+		return Symbol{Code: code}, nil
+	}
+	if len(code) != 6 {
+		return Symbol{}, tsError.Wrapf(tsError.ErrInvalidParameter, "cannot infer exchange of ts_code %q", s)
+	}
+	ex := InferExchange(code)
+	if ex == "" {
+		return Symbol{}, tsError.Wrapf(tsError.ErrInvalidParameter, "cannot infer exchange of ts_code %q", s)
+	}
+	return newStock(s, code, ex)
+}
+
+// newStock 创建沪深北交易所的证券代码，代码主体须为6位（期权为8位）数字
+func newStock(s, code, exchange string) (Symbol, error) {
+	if !isDigits(code) || (len(code) != 6 && len(code) != 8) {
+		return Symbol{}, tsError.Wrapf(tsError.ErrInvalidParameter, "invalid ts_code %q", s)
+	}
+	return Symbol{Code: code, Exchange: exchange, Board: InferBoard(code, exchange)}, nil
+}
+
+// InferExchange 按6位代码前缀推断交易所，无法推断时返回空字符串
+//
+// 上交所：6（A股）、900（B股）、5（基金）、11（可转债）；
+// 北交所：4、8、92；深交所：0、3（A股）、200（B股）、1（基金、可转债）
+func InferExchange(code string) string {
+	if len(code) != 6 || !isDigits(code) {
+		return ""
+	}
+	switch {
+	case code[0] == '6', code[0] == '5', strings.HasPrefix(code, "900"), strings.HasPrefix(code, "11"):
+		return SH
+	case code[0] == '4', code[0] == '8', strings.HasPrefix(code, "92"):
+		return BJ
+	case code[0] == '0', code[0] == '1', code[0] == '2', code[0] == '3':
+		return SZ
+	}
+	return ""
+}
+
+// InferBoard 按代码前缀推断股票所属板块，非股票代码返回空字符串
+//
+// 科创板688、689；创业板300、301；北交所4、8、92开头的股票
+func InferBoard(code, exchange string) string {
+	if len(code) != 6 {
+		return ""
+	}
+	switch exchange {
+	case SH:
+		switch {
+		case strings.HasPrefix(code, "688"), strings.HasPrefix(code, "689"):
+			return BoardSTAR
+		case code[0] == '6', strings.HasPrefix(code, "900"):
+			return BoardMain
+		}
+	case SZ:
+		switch {
+		case strings.HasPrefix(code, "300"), strings.HasPrefix(code, "301"):
+			return BoardChiNext
+		case strings.HasPrefix(code, "00"), strings.HasPrefix(code, "200"):
+			return BoardMain
+		}
+	case BJ:
+		if code[0] == '4' || code[0] == '8' || strings.HasPrefix(code, "92") {
+			return BoardBSE
+		}
+	}
+	return ""
+}
+
+// IsStock 是否为沪深北交易所的股票
+func (s Symbol) IsStock() bool {
+	return s.Board != ""
+}
+
+// TSCode 返回TuShare格式的代码
+func (s Symbol) TSCode() string {
+	if s.Exchange == "" {
+		return s.Code
+	}
+	return s.Code + "." + s.Exchange
+}
+
+// String 返回TuShare格式的代码
+func (s Symbol) String() string {
+	return s.TSCode()
+}
+
+// Format 转换为指定格式，沪深北交易所以外的代码返回TuShare格式
+func (s Symbol) Format(f Format) string {
+	if !isStockExchange(s.Exchange) {
+		return s.TSCode()
+	}
+	switch f {
+	case FormatJoinQuant:
+		return s.Code + "." + map[string]string{SH: "XSHG", SZ: "XSHE", BJ: "BJSE"}[s.Exchange]
+	case FormatPrefix:
+		return strings.ToLower(s.Exchange) + s.Code
+	case FormatBaostock:
+		return strings.ToLower(s.Exchange) + "." + s.Code
+	case FormatYahoo:
+		if s.Exchange == SH {
+			return s.Code + ".SS"
+		}
+	}
+	return s.TSCode()
+}
+
+// Normalize 解析证券代码并转换为TuShare格式
+func Normalize(s string) (string, error) {
+	sym, err := Parse(s)
+	if err != nil {
+		return "", err
+	}
+	return sym.TSCode(), nil
+}
+
+// NormalizeList 规范化逗号分隔的多个证券代码
+func NormalizeList(s string) (string, error) {
+	codes := strings.Split(s, ",")
+	for i, code := range codes {
+		normalized, err := Normalize(code)
+		if err != nil {
+			return "", err
+		}
+		codes[i] = normalized
+	}
+	return strings.Join(codes, ","), nil
+}
+
+// Convert 将证券代码转换为指定格式
+func Convert(s string, f Format) (string, error) {
+	sym, err := Parse(s)
+	if err != nil {
+		return "", err
+	}
+	return sym.Format(f), nil
+}
+
+// isStockExchange 是否为沪深北交易所后缀
+func isStockExchange(s string) bool {
+	return s == SH || s == SZ || s == BJ
+}
+
+// isDigits 是否为非空的纯数字
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// isLetters 是否为非空的纯字母
+func isLetters(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
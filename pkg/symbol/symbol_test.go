@@ -0,0 +1,105 @@
+package symbol
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in    string
+		want  Symbol
+		valid bool
+	}{
+		// 股票
+		{"600000.SH", Symbol{Code: "600000", Exchange: SH, Board: BoardMain}, true},
+		{"600000", Symbol{Code: "600000", Exchange: SH, Board: BoardMain}, true},
+		{"600000.XSHG", Symbol{Code: "600000", Exchange: SH, Board: BoardMain}, true},
+		{"sh600000", Symbol{Code: "600000", Exchange: SH, Board: BoardMain}, true},
+		{"sh.600000", Symbol{Code: "600000", Exchange: SH, Board: BoardMain}, true},
+		{"600000.SS", Symbol{Code: "600000", Exchange: SH, Board: BoardMain}, true},
+		{"688981", Symbol{Code: "688981", Exchange: SH, Board: BoardSTAR}, true},
+		{"300750.XSHE", Symbol{Code: "300750", Exchange: SZ, Board: BoardChiNext}, true},
+		{"430047", Symbol{Code: "430047", Exchange: BJ, Board: BoardBSE}, true},
+
+		// 指数
+		{"000300.SH", Symbol{Code: "000300", Exchange: SH}, true},
+		{"399001.SZ", Symbol{Code: "399001", Exchange: SZ}, true},
+		{"HSI.HI", Symbol{Code: "HSI", Exchange: "HI"}, true},
+
+		// 期货
+		{"RB2405.SHF", Symbol{Code: "RB2405", Exchange: "SHF"}, true},
+		{"if2406.cfx", Symbol{Code: "IF2406", Exchange: "CFX"}, true},
+		{"RB.SHF", Symbol{Code: "RB", Exchange: "SHF"}, true},
+
+		// 期权
+		{"10004354.SH", Symbol{Code: "10004354", Exchange: SH}, true},
+		{"IO2106-C-5000.CFX", Symbol{Code: "IO2106-C-5000", Exchange: "CFX"}, true},
+		{"M2401-C-3000.DCE", Symbol{Code: "M2401-C-3000", Exchange: "DCE"}, true},
+		{"SR401C5000.ZCE", Symbol{Code: "SR401C5000", Exchange: "ZCE"}, true},
+
+		// 港股、美股
+		{"00700.HK", Symbol{Code: "00700", Exchange: "HK"}, true},
+		{"AAPL", Symbol{Code: "AAPL"}, true},
+
+		// 无效代码
+		{"", Symbol{}, false},
+		{"600000.SH.X", Symbol{}, false},
+		{"60000", Symbol{}, false},
+		{"700000", Symbol{}, false},
+		{"6000-0.SH", Symbol{}, false},
+		{"-IO2106.CFX", Symbol{}, false},
+		{"IO2106-.CFX", Symbol{}, false},
+		{"IO2106.C-FX", Symbol{}, false},
+		{"600000 SH", Symbol{}, false},
+	}
+
+	for _, tt := range tests {
+		got, err := Parse(tt.in)
+		if !tt.valid {
+			if err == nil {
+				t.Errorf("Parse(%q) = %+v, want error", tt.in, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Parse(%q) error: %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Parse(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizeList(t *testing.T) {
+	got, err := NormalizeList("600000,sz000001,IO2106-C-5000.CFX,00700.HK")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "600000.SH,000001.SZ,IO2106-C-5000.CFX,00700.HK"; got != want {
+		t.Errorf("NormalizeList = %q, want %q", got, want)
+	}
+}
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		in   string
+		f    Format
+		want string
+	}{
+		{"600000.SH", FormatJoinQuant, "600000.XSHG"},
+		{"000001.SZ", FormatPrefix, "sz000001"},
+		{"430047.BJ", FormatBaostock, "bj.430047"},
+		{"600000.SH", FormatYahoo, "600000.SS"},
+		{"000001.SZ", FormatYahoo, "000001.SZ"},
+		{"M2401-C-3000.DCE", FormatJoinQuant, "M2401-C-3000.DCE"},
+	}
+	for _, tt := range tests {
+		got, err := Convert(tt.in, tt.f)
+		if err != nil {
+			t.Errorf("Convert(%q, %s) error: %v", tt.in, tt.f, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Convert(%q, %s) = %q, want %q", tt.in, tt.f, got, tt.want)
+		}
+	}
+}
@@ -33,6 +33,48 @@ func (df *DataFrame) GetColumn(name string) []interface{} {
 	return result
 }
 
+// Copy 深拷贝DataFrame（行数据逐行复制）
+func (df *DataFrame) Copy() *DataFrame {
+	if df == nil {
+		return nil
+	}
+	columns := make([]string, len(df.Columns))
+	copy(columns, df.Columns)
+	rows := make([]map[string]interface{}, len(df.Rows))
+	for i, row := range df.Rows {
+		newRow := make(map[string]interface{}, len(row))
+		for k, v := range row {
+			newRow[k] = v
+		}
+		rows[i] = newRow
+	}
+	return NewDataFrame(columns, rows)
+}
+
+// HasColumn 判断是否包含指定列
+func (df *DataFrame) HasColumn(name string) bool {
+	for _, col := range df.Columns {
+		if col == name {
+			return true
+		}
+	}
+	return false
+}
+
+// SetColumn 设置指定列的数据，列不存在时追加到列尾
+func (df *DataFrame) SetColumn(name string, values []interface{}) {
+	if !df.HasColumn(name) {
+		df.Columns = append(df.Columns, name)
+	}
+	for i, row := range df.Rows {
+		if i < len(values) {
+			row[name] = values[i]
+		} else {
+			row[name] = nil
+		}
+	}
+}
+
 // ToJSON 将DataFrame转换为JSON
 func (df *DataFrame) ToJSON() ([]byte, error) {
 	return json.Marshal(df)
@@ -0,0 +1,454 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tsError "github.com/Premium-Platform/go-tushare/pkg/errors"
+)
+
+// TuShare接口使用的日期时间格式
+const (
+	DateLayout     = "20060102"
+	DateTimeLayout = "2006-01-02 15:04:05"
+)
+
+// dateLayouts 可解析的日期格式
+var dateLayouts = []string{"20060102", "2006-01-02", "2006/01/02"}
+
+// dateTimeLayouts 可解析的日期时间格式（不含时间的日期按00:00:00处理）
+var dateTimeLayouts = []string{
+	"2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02T15:04:05",
+	"20060102 15:04:05", "20060102150405", "2006/01/02 15:04:05",
+}
+
+// WireFormatter 可转换为TuShare接口参数格式的值
+//
+// Client.Query发送请求前会将实现该接口的参数值转换为字符串，转换失败时返回ErrInvalidParameter
+type WireFormatter interface {
+	Wire() (string, error)
+}
+
+// parseWith 依次尝试按格式解析
+func parseWith(s string, layouts []string) (time.Time, bool) {
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// unquote 解析JSON字符串或数字
+func unquote(data []byte) (string, error) {
+	if string(data) == "null" {
+		return "", nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		return s, nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return "", err
+	}
+	return n.String(), nil
+}
+
+// Date 日期，底层为字符串，可直接使用字符串字面量赋值
+//
+// 支持YYYYMMDD、YYYY-MM-DD、YYYY/MM/DD格式，发送请求时转换为TuShare的YYYYMMDD格式；空值表示不限定
+type Date string
+
+// NewDate 由时间创建日期
+func NewDate(t time.Time) Date {
+	return Date(t.Format(DateLayout))
+}
+
+// ParseDate 解析日期并转换为YYYYMMDD格式
+func ParseDate(s string) (Date, error) {
+	t, err := Date(s).Time()
+	if err != nil {
+		return "", err
+	}
+	return NewDate(t), nil
+}
+
+// Time 转换为时间（UTC）
+func (d Date) Time() (time.Time, error) {
+	s := strings.TrimSpace(string(d))
+	if t, ok := parseWith(s, dateLayouts); ok {
+		return t, nil
+	}
+	return time.Time{}, tsError.Wrapf(tsError.ErrInvalidParameter, "invalid date %q", string(d))
+}
+
+// IsZero 是否为空值
+func (d Date) IsZero() bool {
+	return strings.TrimSpace(string(d)) == ""
+}
+
+// Validate 检查日期格式，空值视为有效
+func (d Date) Validate() error {
+	if d.IsZero() {
+		return nil
+	}
+	_, err := d.Time()
+	return err
+}
+
+// String 返回YYYYMMDD格式，无法解析时返回原始字符串
+func (d Date) String() string {
+	if t, err := d.Time(); err == nil {
+		return t.Format(DateLayout)
+	}
+	return string(d)
+}
+
+// Wire 转换为TuShare接口的YYYYMMDD格式，空值返回空字符串
+func (d Date) Wire() (string, error) {
+	if d.IsZero() {
+		return "", nil
+	}
+	t, err := d.Time()
+	if err != nil {
+		return "", err
+	}
+	return t.Format(DateLayout), nil
+}
+
+// AddDays 加减自然日，无法解析时返回原值
+func (d Date) AddDays(n int) Date {
+	t, err := d.Time()
+	if err != nil {
+		return d
+	}
+	return NewDate(t.AddDate(0, 0, n))
+}
+
+// MarshalJSON 序列化为YYYYMMDD格式的字符串
+func (d Date) MarshalJSON() ([]byte, error) {
+	s, err := d.Wire()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(s)
+}
+
+// UnmarshalJSON 从字符串或数字解析日期
+func (d *Date) UnmarshalJSON(data []byte) error {
+	s, err := unquote(data)
+	if err != nil {
+		return tsError.Wrapf(tsError.ErrInvalidParameter, "invalid date %s", string(data))
+	}
+	return d.UnmarshalText([]byte(s))
+}
+
+// MarshalText 序列化为YYYYMMDD格式（用于CSV等文本格式）
+func (d Date) MarshalText() ([]byte, error) {
+	s, err := d.Wire()
+	return []byte(s), err
+}
+
+// UnmarshalText 从文本解析日期
+func (d *Date) UnmarshalText(text []byte) error {
+	if Date(text).IsZero() {
+		*d = ""
+		return nil
+	}
+	parsed, err := ParseDate(string(text))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// DateTime 日期时间，底层为字符串，可直接使用字符串字面量赋值
+//
+// 支持YYYY-MM-DD HH:MM:SS等格式，也可以只有日期（按00:00:00处理）；发送请求时转换为YYYY-MM-DD HH:MM:SS格式
+type DateTime string
+
+// NewDateTime 由时间创建日期时间
+func NewDateTime(t time.Time) DateTime {
+	return DateTime(t.Format(DateTimeLayout))
+}
+
+// ParseDateTime 解析日期时间并转换为YYYY-MM-DD HH:MM:SS格式
+func ParseDateTime(s string) (DateTime, error) {
+	t, err := DateTime(s).Time()
+	if err != nil {
+		return "", err
+	}
+	return NewDateTime(t), nil
+}
+
+// Time 转换为时间（UTC）
+func (dt DateTime) Time() (time.Time, error) {
+	s := strings.TrimSpace(string(dt))
+	if t, ok := parseWith(s, dateTimeLayouts); ok {
+		return t, nil
+	}
+	if t, ok := parseWith(s, dateLayouts); ok {
+		return t, nil
+	}
+	return time.Time{}, tsError.Wrapf(tsError.ErrInvalidParameter, "invalid datetime %q", string(dt))
+}
+
+// HasTime 是否包含时间部分
+func (dt DateTime) HasTime() bool {
+	_, ok := parseWith(strings.TrimSpace(string(dt)), dateTimeLayouts)
+	return ok
+}
+
+// Date 获取日期部分，无法解析时返回空值
+func (dt DateTime) Date() Date {
+	t, err := dt.Time()
+	if err != nil {
+		return ""
+	}
+	return NewDate(t)
+}
+
+// IsZero 是否为空值
+func (dt DateTime) IsZero() bool {
+	return strings.TrimSpace(string(dt)) == ""
+}
+
+// Validate 检查日期时间格式，空值视为有效
+func (dt DateTime) Validate() error {
+	if dt.IsZero() {
+		return nil
+	}
+	_, err := dt.Time()
+	return err
+}
+
+// String 返回YYYY-MM-DD HH:MM:SS格式，无法解析时返回原始字符串
+func (dt DateTime) String() string {
+	if t, err := dt.Time(); err == nil {
+		return t.Format(DateTimeLayout)
+	}
+	return string(dt)
+}
+
+// Wire 转换为TuShare接口的YYYY-MM-DD HH:MM:SS格式，空值返回空字符串
+func (dt DateTime) Wire() (string, error) {
+	if dt.IsZero() {
+		return "", nil
+	}
+	t, err := dt.Time()
+	if err != nil {
+		return "", err
+	}
+	return t.Format(DateTimeLayout), nil
+}
+
+// MarshalJSON 序列化为YYYY-MM-DD HH:MM:SS格式的字符串
+func (dt DateTime) MarshalJSON() ([]byte, error) {
+	s, err := dt.Wire()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(s)
+}
+
+// UnmarshalJSON 从字符串解析日期时间
+func (dt *DateTime) UnmarshalJSON(data []byte) error {
+	s, err := unquote(data)
+	if err != nil {
+		return tsError.Wrapf(tsError.ErrInvalidParameter, "invalid datetime %s", string(data))
+	}
+	return dt.UnmarshalText([]byte(s))
+}
+
+// MarshalText 序列化为YYYY-MM-DD HH:MM:SS格式（用于CSV等文本格式）
+func (dt DateTime) MarshalText() ([]byte, error) {
+	s, err := dt.Wire()
+	return []byte(s), err
+}
+
+// UnmarshalText 从文本解析日期时间
+func (dt *DateTime) UnmarshalText(text []byte) error {
+	if DateTime(text).IsZero() {
+		*dt = ""
+		return nil
+	}
+	parsed, err := ParseDateTime(string(text))
+	if err != nil {
+		return err
+	}
+	*dt = parsed
+	return nil
+}
+
+// Period 财务报告期，底层为字符串，可直接使用字符串字面量赋值
+//
+// 报告期为季度末日期（0331、0630、0930、1231），支持YYYYMMDD、YYYY-MM-DD和YYYYQn格式，
+// 发送请求时转换为YYYYMMDD格式
+type Period string
+
+// quarterEnds 各季度末的月日
+var quarterEnds = []string{"0331", "0630", "0930", "1231"}
+
+// NewPeriod 由年份和季度（1-4）创建报告期
+func NewPeriod(year, quarter int) Period {
+	if quarter < 1 || quarter > 4 {
+		return ""
+	}
+	return Period(fmt.Sprintf("%04d%s", year, quarterEnds[quarter-1]))
+}
+
+// AnnualPeriod 年报报告期（12月31日）
+func AnnualPeriod(year int) Period {
+	return NewPeriod(year, 4)
+}
+
+// ParsePeriod 解析报告期并转换为YYYYMMDD格式
+func ParsePeriod(s string) (Period, error) {
+	year, quarter, err := Period(s).parse()
+	if err != nil {
+		return "", err
+	}
+	return NewPeriod(year, quarter), nil
+}
+
+// parse 解析为年份和季度
+func (p Period) parse() (int, int, error) {
+	s := strings.ToUpper(strings.TrimSpace(string(p)))
+
+	// YYYYQn
+	if len(s) == 6 && s[4] == 'Q' {
+		year, err1 := strconv.Atoi(s[:4])
+		quarter, err2 := strconv.Atoi(s[5:])
+		if err1 == nil && err2 == nil && quarter >= 1 && quarter <= 4 {
+			return year, quarter, nil
+		}
+	}
+
+	if t, ok := parseWith(s, dateLayouts); ok {
+		md := t.Format("0102")
+		for i, end := range quarterEnds {
+			if md == end {
+				return t.Year(), i + 1, nil
+			}
+		}
+	}
+	return 0, 0, tsError.Wrapf(tsError.ErrInvalidParameter, "invalid report period %q", string(p))
+}
+
+// Year 报告期所在年份，无法解析时为0
+func (p Period) Year() int {
+	year, _, _ := p.parse()
+	return year
+}
+
+// Quarter 报告期所在季度（1-4），无法解析时为0
+func (p Period) Quarter() int {
+	_, quarter, _ := p.parse()
+	return quarter
+}
+
+// AddQuarters 加减季度，无法解析时返回原值
+func (p Period) AddQuarters(n int) Period {
+	year, quarter, err := p.parse()
+	if err != nil {
+		return p
+	}
+	idx := year*4 + quarter - 1 + n
+	return NewPeriod(idx/4, idx%4+1)
+}
+
+// Prev 上一个报告期
+func (p Period) Prev() Period {
+	return p.AddQuarters(-1)
+}
+
+// Next 下一个报告期
+func (p Period) Next() Period {
+	return p.AddQuarters(1)
+}
+
+// Date 报告期对应的日期，无法解析时返回空值
+func (p Period) Date() Date {
+	year, quarter, err := p.parse()
+	if err != nil {
+		return ""
+	}
+	return Date(NewPeriod(year, quarter))
+}
+
+// IsZero 是否为空值
+func (p Period) IsZero() bool {
+	return strings.TrimSpace(string(p)) == ""
+}
+
+// Validate 检查报告期格式，空值视为有效
+func (p Period) Validate() error {
+	if p.IsZero() {
+		return nil
+	}
+	_, _, err := p.parse()
+	return err
+}
+
+// String 返回YYYYMMDD格式，无法解析时返回原始字符串
+func (p Period) String() string {
+	if year, quarter, err := p.parse(); err == nil {
+		return string(NewPeriod(year, quarter))
+	}
+	return string(p)
+}
+
+// Wire 转换为TuShare接口的YYYYMMDD格式，空值返回空字符串
+func (p Period) Wire() (string, error) {
+	if p.IsZero() {
+		return "", nil
+	}
+	year, quarter, err := p.parse()
+	if err != nil {
+		return "", err
+	}
+	return string(NewPeriod(year, quarter)), nil
+}
+
+// MarshalJSON 序列化为YYYYMMDD格式的字符串
+func (p Period) MarshalJSON() ([]byte, error) {
+	s, err := p.Wire()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(s)
+}
+
+// UnmarshalJSON 从字符串或数字解析报告期
+func (p *Period) UnmarshalJSON(data []byte) error {
+	s, err := unquote(data)
+	if err != nil {
+		return tsError.Wrapf(tsError.ErrInvalidParameter, "invalid report period %s", string(data))
+	}
+	return p.UnmarshalText([]byte(s))
+}
+
+// MarshalText 序列化为YYYYMMDD格式（用于CSV等文本格式）
+func (p Period) MarshalText() ([]byte, error) {
+	s, err := p.Wire()
+	return []byte(s), err
+}
+
+// UnmarshalText 从文本解析报告期
+func (p *Period) UnmarshalText(text []byte) error {
+	if Period(text).IsZero() {
+		*p = ""
+		return nil
+	}
+	parsed, err := ParsePeriod(string(text))
+	if err != nil {
+		return err
+	}
+	*p = parsed
+	return nil
+}
@@ -0,0 +1,84 @@
+package types
+
+import (
+	"strings"
+)
+
+// LeftJoin 按指定键列左连接另一个DataFrame（原地修改）
+//
+// cols为空时合并other中除键列外的全部列；左表中没有匹配行的记录对应列为nil。
+// other中存在重复键时使用最后一行。
+func (df *DataFrame) LeftJoin(other *DataFrame, on []string, cols []string) *DataFrame {
+	if other == nil {
+		return df
+	}
+
+	if len(cols) == 0 {
+		for _, col := range other.Columns {
+			if !containsString(on, col) {
+				cols = append(cols, col)
+			}
+		}
+	}
+
+	index := make(map[string]map[string]interface{}, len(other.Rows))
+	for _, row := range other.Rows {
+		index[joinKey(row, on)] = row
+	}
+
+	for _, col := range cols {
+		if !df.HasColumn(col) {
+			df.Columns = append(df.Columns, col)
+		}
+	}
+
+	for _, row := range df.Rows {
+		match := index[joinKey(row, on)]
+		for _, col := range cols {
+			if match != nil {
+				row[col] = match[col]
+			} else {
+				row[col] = nil
+			}
+		}
+	}
+
+	return df
+}
+
+// joinKey 构造连接键
+func joinKey(row map[string]interface{}, on []string) string {
+	parts := make([]string, len(on))
+	for i, col := range on {
+		parts[i] = toString(row[col])
+	}
+	return strings.Join(parts, "\x00")
+}
+
+// containsString 判断字符串切片是否包含指定值
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Concat 按行拼接多个DataFrame，列为各DataFrame列的并集（按首次出现顺序）
+func Concat(frames ...*DataFrame) *DataFrame {
+	columns := make([]string, 0)
+	rows := make([]map[string]interface{}, 0)
+	for _, df := range frames {
+		if df == nil {
+			continue
+		}
+		for _, col := range df.Columns {
+			if !containsString(columns, col) {
+				columns = append(columns, col)
+			}
+		}
+		rows = append(rows, df.Rows...)
+	}
+	return NewDataFrame(columns, rows)
+}
@@ -0,0 +1,258 @@
+package types
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	// HowAny 任意一列缺失即删除该行
+	HowAny = "any"
+	// HowAll 所有列均缺失才删除该行
+	HowAll = "all"
+)
+
+// IsNA 判断值是否为缺失值（nil或NaN）
+func IsNA(v interface{}) bool {
+	switch val := v.(type) {
+	case nil:
+		return true
+	case float64:
+		return math.IsNaN(val)
+	case float32:
+		return math.IsNaN(float64(val))
+	}
+	return false
+}
+
+// ToFloat64 将值转换为浮点数，缺失值或无法转换时返回false
+func ToFloat64(v interface{}) (float64, bool) {
+	switch val := v.(type) {
+	case nil:
+		return 0, false
+	case float64:
+		return val, !math.IsNaN(val)
+	case float32:
+		return float64(val), !math.IsNaN(float64(val))
+	case int:
+		return float64(val), true
+	case int32:
+		return float64(val), true
+	case int64:
+		return float64(val), true
+	case json.Number:
+		f, err := val.Float64()
+		return f, err == nil
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(val), 64)
+		if err != nil || math.IsNaN(f) {
+			return 0, false
+		}
+		return f, true
+	}
+	return 0, false
+}
+
+// Float64Column 获取指定列的浮点数数据，缺失值为NaN
+func (df *DataFrame) Float64Column(name string) []float64 {
+	result := make([]float64, len(df.Rows))
+	for i, row := range df.Rows {
+		if f, ok := ToFloat64(row[name]); ok {
+			result[i] = f
+		} else {
+			result[i] = math.NaN()
+		}
+	}
+	return result
+}
+
+// FloatsToColumn 将浮点数切片转换为列数据，NaN转换为nil
+func FloatsToColumn(values []float64) []interface{} {
+	result := make([]interface{}, len(values))
+	for i, v := range values {
+		if !math.IsNaN(v) {
+			result[i] = v
+		}
+	}
+	return result
+}
+
+// DropNA 删除包含缺失值的行
+//
+// cols为空时检查全部列；how为HowAny时任意一列缺失即删除，为HowAll时所有列缺失才删除
+func (df *DataFrame) DropNA(cols []string, how string) *DataFrame {
+	if len(cols) == 0 {
+		cols = df.Columns
+	}
+	if how == "" {
+		how = HowAny
+	}
+
+	result := df.Copy()
+	rows := result.Rows[:0]
+	for _, row := range result.Rows {
+		missing := 0
+		for _, col := range cols {
+			if IsNA(row[col]) {
+				missing++
+			}
+		}
+
+		drop := false
+		switch how {
+		case HowAll:
+			drop = len(cols) > 0 && missing == len(cols)
+		default:
+			drop = missing > 0
+		}
+
+		if !drop {
+			rows = append(rows, row)
+		}
+	}
+	result.Rows = rows
+	return result
+}
+
+// FillNA 使用指定值填充缺失值
+//
+// value为map[string]interface{}时按列填充，仅处理map中出现的列；否则使用同一个值填充全部列
+func (df *DataFrame) FillNA(value interface{}) *DataFrame {
+	result := df.Copy()
+
+	if values, ok := value.(map[string]interface{}); ok {
+		for _, row := range result.Rows {
+			for col, v := range values {
+				if IsNA(row[col]) {
+					row[col] = v
+				}
+			}
+		}
+		return result
+	}
+
+	for _, row := range result.Rows {
+		for _, col := range result.Columns {
+			if IsNA(row[col]) {
+				row[col] = value
+			}
+		}
+	}
+	return result
+}
+
+// FFill 使用前一个有效值向后填充缺失值
+//
+// cols为空时处理全部列；limit大于0时，每段连续缺失最多填充limit行
+func (df *DataFrame) FFill(cols []string, limit int) *DataFrame {
+	result := df.Copy()
+	if len(cols) == 0 {
+		cols = result.Columns
+	}
+
+	for _, col := range cols {
+		var last interface{}
+		filled := 0
+		for _, row := range result.Rows {
+			if !IsNA(row[col]) {
+				last = row[col]
+				filled = 0
+				continue
+			}
+			if last == nil || (limit > 0 && filled >= limit) {
+				continue
+			}
+			row[col] = last
+			filled++
+		}
+	}
+	return result
+}
+
+// BFill 使用后一个有效值向前填充缺失值
+//
+// cols为空时处理全部列；limit大于0时，每段连续缺失最多填充limit行
+func (df *DataFrame) BFill(cols []string, limit int) *DataFrame {
+	result := df.Copy()
+	if len(cols) == 0 {
+		cols = result.Columns
+	}
+
+	for _, col := range cols {
+		var next interface{}
+		filled := 0
+		for i := len(result.Rows) - 1; i >= 0; i-- {
+			row := result.Rows[i]
+			if !IsNA(row[col]) {
+				next = row[col]
+				filled = 0
+				continue
+			}
+			if next == nil || (limit > 0 && filled >= limit) {
+				continue
+			}
+			row[col] = next
+			filled++
+		}
+	}
+	return result
+}
+
+// Interpolate 对数值列进行线性插值
+//
+// cols为空时只处理数值列（非缺失值均为数值类型的列），日期、代码等字符串列不插值；
+// 按行序号等距插值，首尾的缺失值无法插值，保持为缺失；非数值内容视为缺失
+func (df *DataFrame) Interpolate(cols []string) *DataFrame {
+	result := df.Copy()
+	if len(cols) == 0 {
+		cols = result.numericColumns()
+	}
+
+	for _, col := range cols {
+		values := result.Float64Column(col)
+
+		prev := -1
+		for i, v := range values {
+			if math.IsNaN(v) {
+				continue
+			}
+			if prev >= 0 && i-prev > 1 {
+				step := (v - values[prev]) / float64(i-prev)
+				for j := prev + 1; j < i; j++ {
+					result.Rows[j][col] = values[prev] + step*float64(j-prev)
+				}
+			}
+			prev = i
+		}
+	}
+	return result
+}
+
+// numericColumns 获取非缺失值均为数值类型的列，全部缺失的列不包含在内
+func (df *DataFrame) numericColumns() []string {
+	cols := make([]string, 0, len(df.Columns))
+	for _, col := range df.Columns {
+		numeric, seen := true, false
+		for _, row := range df.Rows {
+			v := row[col]
+			if IsNA(v) {
+				continue
+			}
+			seen = true
+			switch v.(type) {
+			case float64, float32, int, int32, int64, json.Number:
+			default:
+				numeric = false
+			}
+			if !numeric {
+				break
+			}
+		}
+		if numeric && seen {
+			cols = append(cols, col)
+		}
+	}
+	return cols
+}
@@ -0,0 +1,27 @@
+package types
+
+import "testing"
+
+// TestInterpolateDefaultColumns cols为空时只对数值列插值，字符串列保持不变
+func TestInterpolateDefaultColumns(t *testing.T) {
+	rows := []map[string]interface{}{
+		{"trade_date": "20240102", "code": "1", "close": 10.0},
+		{"trade_date": nil, "code": nil, "close": nil},
+		{"trade_date": "20240104", "code": "3", "close": 12.0},
+	}
+	df := NewDataFrame([]string{"trade_date", "code", "close"}, rows).Interpolate(nil)
+
+	row := df.Rows[1]
+	if row["close"] != 11.0 {
+		t.Errorf("close = %v, want 11", row["close"])
+	}
+	if row["trade_date"] != nil || row["code"] != nil {
+		t.Errorf("string columns interpolated: trade_date=%v code=%v", row["trade_date"], row["code"])
+	}
+
+	// 指定列时按指定的列插值
+	df = NewDataFrame([]string{"trade_date", "code", "close"}, rows).Interpolate([]string{"code"})
+	if df.Rows[1]["code"] != 2.0 {
+		t.Errorf("code = %v, want 2", df.Rows[1]["code"])
+	}
+}
@@ -0,0 +1,44 @@
+package types
+
+import (
+	"sort"
+)
+
+// SortBy 按指定列对行进行稳定排序（原地排序），缺失值排在最后
+//
+// 两个值均可转换为数值时按数值比较，否则按字符串比较
+func (df *DataFrame) SortBy(col string, ascending bool) *DataFrame {
+	sort.SliceStable(df.Rows, func(i, j int) bool {
+		a, b := df.Rows[i][col], df.Rows[j][col]
+		if IsNA(a) || IsNA(b) {
+			return !IsNA(a) && IsNA(b)
+		}
+		if ascending {
+			return lessValue(a, b)
+		}
+		return lessValue(b, a)
+	})
+	return df
+}
+
+// Reverse 反转行顺序（原地反转）
+func (df *DataFrame) Reverse() *DataFrame {
+	for i, j := 0, len(df.Rows)-1; i < j; i, j = i+1, j-1 {
+		df.Rows[i], df.Rows[j] = df.Rows[j], df.Rows[i]
+	}
+	return df
+}
+
+// lessValue 比较两个非缺失值的大小
+func lessValue(a, b interface{}) bool {
+	_, aIsString := a.(string)
+	_, bIsString := b.(string)
+	if !aIsString || !bIsString {
+		fa, okA := ToFloat64(a)
+		fb, okB := ToFloat64(b)
+		if okA && okB {
+			return fa < fb
+		}
+	}
+	return toString(a) < toString(b)
+}
@@ -0,0 +1,330 @@
+package types
+
+import (
+	"math"
+)
+
+// Rolling 滑动窗口（window为0时为扩展窗口）
+//
+// 窗口按行计数，缺失值不参与计算；窗口内有效值个数少于minPeriods时结果为nil
+type Rolling struct {
+	df         *DataFrame
+	col        string
+	window     int
+	minPeriods int
+	groupBy    string
+}
+
+// Rolling 创建指定列的滑动窗口，min_periods默认等于窗口大小
+func (df *DataFrame) Rolling(col string, window int) *Rolling {
+	if window < 1 {
+		window = 1
+	}
+	return &Rolling{
+		df:         df,
+		col:        col,
+		window:     window,
+		minPeriods: window,
+	}
+}
+
+// Expanding 创建指定列的扩展窗口，min_periods默认为1
+func (df *DataFrame) Expanding(col string) *Rolling {
+	return &Rolling{
+		df:         df,
+		col:        col,
+		minPeriods: 1,
+	}
+}
+
+// MinPeriods 设置产生结果所需的最少有效值个数
+func (r *Rolling) MinPeriods(n int) *Rolling {
+	if n < 1 {
+		n = 1
+	}
+	r.minPeriods = n
+	return r
+}
+
+// GroupBy 按指定列分组计算（如ts_code），各组窗口互不跨越
+func (r *Rolling) GroupBy(col string) *Rolling {
+	r.groupBy = col
+	return r
+}
+
+// Mean 窗口均值
+func (r *Rolling) Mean() []interface{} {
+	return r.run(func(values []float64, out []float64) {
+		r.sumMean(values, out, true)
+	})
+}
+
+// Sum 窗口求和
+func (r *Rolling) Sum() []interface{} {
+	return r.run(func(values []float64, out []float64) {
+		r.sumMean(values, out, false)
+	})
+}
+
+// Std 窗口样本标准差（自由度n-1）
+//
+// 以首个有效值为基准平移后用Welford算法增量更新均值和离差平方和，
+// 避免价格等数值较大而波动较小时E[x²]-E[x]²的相消误差
+func (r *Rolling) Std() []interface{} {
+	return r.run(func(values []float64, out []float64) {
+		var shift, mean, m2 float64
+		count, seen := 0, false
+		for i, v := range values {
+			if !math.IsNaN(v) {
+				if !seen {
+					shift, seen = v, true
+				}
+				x := v - shift
+				count++
+				delta := x - mean
+				mean += delta / float64(count)
+				m2 += delta * (x - mean)
+			}
+			if r.window > 0 && i >= r.window {
+				if old := values[i-r.window]; !math.IsNaN(old) {
+					x := old - shift
+					count--
+					if count == 0 {
+						mean, m2 = 0, 0
+					} else {
+						delta := x - mean
+						mean -= delta / float64(count)
+						m2 -= delta * (x - mean)
+					}
+				}
+			}
+
+			out[i] = math.NaN()
+			if count >= r.minPeriods && count > 1 {
+				if m2 < 0 {
+					m2 = 0
+				}
+				out[i] = math.Sqrt(m2 / float64(count-1))
+			}
+		}
+	})
+}
+
+// Min 窗口最小值
+func (r *Rolling) Min() []interface{} {
+	return r.run(func(values []float64, out []float64) {
+		r.extreme(values, out, func(a, b float64) bool { return a <= b })
+	})
+}
+
+// Max 窗口最大值
+func (r *Rolling) Max() []interface{} {
+	return r.run(func(values []float64, out []float64) {
+		r.extreme(values, out, func(a, b float64) bool { return a >= b })
+	})
+}
+
+// Apply 对每个窗口内的有效值调用自定义函数，复杂度为O(n·window)
+func (r *Rolling) Apply(fn func(values []float64) float64) []interface{} {
+	return r.run(func(values []float64, out []float64) {
+		window := make([]float64, 0, len(values))
+		for i := range values {
+			start := 0
+			if r.window > 0 && i-r.window+1 > 0 {
+				start = i - r.window + 1
+			}
+
+			window = window[:0]
+			for _, v := range values[start : i+1] {
+				if !math.IsNaN(v) {
+					window = append(window, v)
+				}
+			}
+
+			out[i] = math.NaN()
+			if len(window) >= r.minPeriods {
+				out[i] = fn(window)
+			}
+		}
+	})
+}
+
+// run 按分组执行窗口计算，并将结果写回原始行序
+func (r *Rolling) run(calc func(values []float64, out []float64)) []interface{} {
+	all := r.df.Float64Column(r.col)
+	result := make([]float64, len(all))
+
+	for _, idx := range r.df.groupIndices(r.groupBy) {
+		values := make([]float64, len(idx))
+		for i, rowIdx := range idx {
+			values[i] = all[rowIdx]
+		}
+		out := make([]float64, len(idx))
+		calc(values, out)
+		for i, rowIdx := range idx {
+			result[rowIdx] = out[i]
+		}
+	}
+
+	return FloatsToColumn(result)
+}
+
+// sumMean 增量计算窗口和或均值
+func (r *Rolling) sumMean(values []float64, out []float64, mean bool) {
+	var sum float64
+	count := 0
+	for i, v := range values {
+		if !math.IsNaN(v) {
+			sum += v
+			count++
+		}
+		if r.window > 0 && i >= r.window {
+			if old := values[i-r.window]; !math.IsNaN(old) {
+				sum -= old
+				count--
+			}
+		}
+
+		out[i] = math.NaN()
+		if count >= r.minPeriods {
+			if mean {
+				out[i] = sum / float64(count)
+			} else {
+				out[i] = sum
+			}
+		}
+	}
+}
+
+// extreme 使用单调队列计算窗口极值，better(a, b)为true时a优于b
+func (r *Rolling) extreme(values []float64, out []float64, better func(a, b float64) bool) {
+	deque := make([]int, 0, len(values))
+	count := 0
+	for i, v := range values {
+		if r.window > 0 && i >= r.window {
+			if !math.IsNaN(values[i-r.window]) {
+				count--
+			}
+			if len(deque) > 0 && deque[0] <= i-r.window {
+				deque = deque[1:]
+			}
+		}
+
+		if !math.IsNaN(v) {
+			count++
+			for len(deque) > 0 && better(v, values[deque[len(deque)-1]]) {
+				deque = deque[:len(deque)-1]
+			}
+			deque = append(deque, i)
+		}
+
+		out[i] = math.NaN()
+		if count >= r.minPeriods && len(deque) > 0 {
+			out[i] = values[deque[0]]
+		}
+	}
+}
+
+// groupIndices 按列值分组，返回各组的行号（组按首次出现顺序排列，组内保持原始行序）
+func (df *DataFrame) groupIndices(col string) [][]int {
+	if col == "" {
+		idx := make([]int, len(df.Rows))
+		for i := range idx {
+			idx[i] = i
+		}
+		return [][]int{idx}
+	}
+
+	order := make([]string, 0)
+	groups := make(map[string][]int)
+	for i, row := range df.Rows {
+		key := toString(row[col])
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], i)
+	}
+
+	result := make([][]int, 0, len(order))
+	for _, key := range order {
+		result = append(result, groups[key])
+	}
+	return result
+}
+
+// Shift 将列数据平移periods行（正数向后平移，即取前periods行的值），可选按列分组
+func (df *DataFrame) Shift(col string, periods int, groupBy ...string) []interface{} {
+	values := df.GetColumn(col)
+	result := make([]interface{}, len(values))
+
+	for _, idx := range df.groupIndices(firstOrEmpty(groupBy)) {
+		for i, rowIdx := range idx {
+			src := i - periods
+			if src >= 0 && src < len(idx) {
+				result[rowIdx] = values[idx[src]]
+			}
+		}
+	}
+	return result
+}
+
+// Diff 计算与前periods行的差值，可选按列分组
+func (df *DataFrame) Diff(col string, periods int, groupBy ...string) []interface{} {
+	return df.pairwise(col, periods, firstOrEmpty(groupBy), func(cur, prev float64) float64 {
+		return cur - prev
+	})
+}
+
+// PctChange 计算相对前periods行的变化率，可选按列分组
+func (df *DataFrame) PctChange(col string, periods int, groupBy ...string) []interface{} {
+	return df.pairwise(col, periods, firstOrEmpty(groupBy), func(cur, prev float64) float64 {
+		if prev == 0 {
+			return math.NaN()
+		}
+		return cur/prev - 1
+	})
+}
+
+// pairwise 对当前行与前periods行的数值做二元运算
+func (df *DataFrame) pairwise(col string, periods int, groupBy string, op func(cur, prev float64) float64) []interface{} {
+	values := df.Float64Column(col)
+	result := make([]float64, len(values))
+	for i := range result {
+		result[i] = math.NaN()
+	}
+
+	for _, idx := range df.groupIndices(groupBy) {
+		for i, rowIdx := range idx {
+			src := i - periods
+			if src < 0 || src >= len(idx) {
+				continue
+			}
+			cur, prev := values[rowIdx], values[idx[src]]
+			if math.IsNaN(cur) || math.IsNaN(prev) {
+				continue
+			}
+			result[rowIdx] = op(cur, prev)
+		}
+	}
+	return FloatsToColumn(result)
+}
+
+// ShiftSeries 平移一组数据（正数向后平移），空出的位置为nil
+func ShiftSeries(values []interface{}, periods int) []interface{} {
+	result := make([]interface{}, len(values))
+	for i := range values {
+		src := i - periods
+		if src >= 0 && src < len(values) {
+			result[i] = values[src]
+		}
+	}
+	return result
+}
+
+// firstOrEmpty 返回可选参数中的第一个值
+func firstOrEmpty(values []string) string {
+	if len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
@@ -0,0 +1,124 @@
+package types
+
+import (
+	"math"
+	"testing"
+)
+
+// windowFrame 由一组值构造单列数据，nil表示缺失
+func windowFrame(values ...interface{}) *DataFrame {
+	rows := make([]map[string]interface{}, len(values))
+	for i, v := range values {
+		rows[i] = map[string]interface{}{"v": v}
+	}
+	return NewDataFrame([]string{"v"}, rows)
+}
+
+// checkColumn 比较计算结果，want中的nil表示结果应为nil
+func checkColumn(t *testing.T, name string, got []interface{}, want ...interface{}) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("%s: got %d values, want %d", name, len(got), len(want))
+	}
+	for i := range want {
+		if want[i] == nil || got[i] == nil {
+			if want[i] != got[i] {
+				t.Errorf("%s[%d] = %v, want %v", name, i, got[i], want[i])
+			}
+			continue
+		}
+		if math.Abs(got[i].(float64)-want[i].(float64)) > 1e-9 {
+			t.Errorf("%s[%d] = %v, want %v", name, i, got[i], want[i])
+		}
+	}
+}
+
+func TestRolling(t *testing.T) {
+	df := windowFrame(1.0, 3.0, 2.0, 5.0, 4.0)
+	r := df.Rolling("v", 3)
+
+	checkColumn(t, "mean", r.Mean(), nil, nil, 2.0, 10.0/3, 11.0/3)
+	checkColumn(t, "sum", r.Sum(), nil, nil, 6.0, 10.0, 11.0)
+	checkColumn(t, "min", r.Min(), nil, nil, 1.0, 2.0, 2.0)
+	checkColumn(t, "max", r.Max(), nil, nil, 3.0, 5.0, 5.0)
+	checkColumn(t, "std", r.Std(), nil, nil, 1.0, math.Sqrt(7.0/3), 1.5275252316519468)
+	checkColumn(t, "apply", r.Apply(func(w []float64) float64 { return w[len(w)-1] - w[0] }), nil, nil, 1.0, 2.0, 2.0)
+
+	// MinPeriods小于窗口时窗口未满也产生结果，标准差至少需要两个值
+	r = df.Rolling("v", 3).MinPeriods(1)
+	checkColumn(t, "mean min_periods=1", r.Mean(), 1.0, 2.0, 2.0, 10.0/3, 11.0/3)
+	checkColumn(t, "std min_periods=1", r.Std(), nil, math.Sqrt2, 1.0, math.Sqrt(7.0/3), 1.5275252316519468)
+}
+
+func TestExpanding(t *testing.T) {
+	df := windowFrame(1.0, 3.0, 2.0, 5.0)
+	r := df.Expanding("v")
+
+	checkColumn(t, "mean", r.Mean(), 1.0, 2.0, 2.0, 2.75)
+	checkColumn(t, "sum", r.Sum(), 1.0, 4.0, 6.0, 11.0)
+	checkColumn(t, "min", r.Min(), 1.0, 1.0, 1.0, 1.0)
+	checkColumn(t, "max", r.Max(), 1.0, 3.0, 3.0, 5.0)
+	checkColumn(t, "std", r.Std(), nil, math.Sqrt2, 1.0, 1.707825127659933)
+	checkColumn(t, "mean min_periods=3", df.Expanding("v").MinPeriods(3).Mean(), nil, nil, 2.0, 2.75)
+}
+
+// TestRollingMissing 缺失值（nil、NaN、无法转换的值）不参与计算，也不计入有效值个数
+func TestRollingMissing(t *testing.T) {
+	df := windowFrame(1.0, nil, 3.0, math.NaN(), "x", 6.0, 7.0)
+	r := df.Rolling("v", 3).MinPeriods(2)
+
+	// 窗口：[1] [1,-] [1,-,3] [-,3,-] [3,-,-] [-,-,6] [-,6,7]
+	checkColumn(t, "mean", r.Mean(), nil, nil, 2.0, nil, nil, nil, 6.5)
+	checkColumn(t, "sum", r.Sum(), nil, nil, 4.0, nil, nil, nil, 13.0)
+	checkColumn(t, "min", r.Min(), nil, nil, 1.0, nil, nil, nil, 6.0)
+	checkColumn(t, "max", r.Max(), nil, nil, 3.0, nil, nil, nil, 7.0)
+	checkColumn(t, "std", r.Std(), nil, nil, math.Sqrt2, nil, nil, nil, math.Sqrt(0.5))
+	checkColumn(t, "apply", r.Apply(func(w []float64) float64 { return float64(len(w)) }), nil, nil, 2.0, nil, nil, nil, 2.0)
+
+	// 窗口内全部缺失后重新开始累计
+	checkColumn(t, "expanding std", df.Expanding("v").Std(), nil, nil, math.Sqrt2, math.Sqrt2, math.Sqrt2, 2.5166114784235836, math.Sqrt(22.75/3))
+}
+
+// TestRollingStdStable 数值较大、波动较小时标准差仍然准确
+func TestRollingStdStable(t *testing.T) {
+	const base = 1e9
+	df := windowFrame(base+1, base+2, base+3, base+4, base+5, base+4, base+3)
+
+	checkColumn(t, "std", df.Rolling("v", 3).Std(), nil, nil, 1.0, 1.0, 1.0, math.Sqrt(1.0/3), 1.0)
+	checkColumn(t, "constant", windowFrame(base, base, base, base).Rolling("v", 2).Std(), nil, 0.0, 0.0, 0.0)
+
+	// 与逐窗口两遍计算的结果一致（误差在输入值本身的精度范围内）
+	values := make([]interface{}, 200)
+	for i := range values {
+		values[i] = 1e8 + math.Sin(float64(i))*0.01
+	}
+	got := windowFrame(values...).Rolling("v", 20).Std()
+	for i := 19; i < len(values); i++ {
+		var mean, ss float64
+		for _, v := range values[i-19 : i+1] {
+			mean += v.(float64) / 20
+		}
+		for _, v := range values[i-19 : i+1] {
+			ss += (v.(float64) - mean) * (v.(float64) - mean)
+		}
+		want := math.Sqrt(ss / 19)
+		if math.Abs(got[i].(float64)-want) > 1e-5*want {
+			t.Fatalf("std[%d] = %v, want %v", i, got[i], want)
+		}
+	}
+}
+
+func TestRollingGroupBy(t *testing.T) {
+	rows := []map[string]interface{}{
+		{"ts_code": "A", "v": 1.0},
+		{"ts_code": "B", "v": 10.0},
+		{"ts_code": "A", "v": 3.0},
+		{"ts_code": "B", "v": 20.0},
+		{"ts_code": "A", "v": 5.0},
+	}
+	df := NewDataFrame([]string{"ts_code", "v"}, rows)
+
+	// 各组窗口互不跨越，结果写回原始行序
+	checkColumn(t, "mean", df.Rolling("v", 2).GroupBy("ts_code").Mean(), nil, nil, 2.0, 15.0, 4.0)
+	checkColumn(t, "max", df.Expanding("v").GroupBy("ts_code").Max(), 1.0, 10.0, 3.0, 20.0, 5.0)
+}